@@ -1,26 +1,79 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/bfg-dev/crypto-core/pkg/api"
 	"github.com/bfg-dev/crypto-core/pkg/api/middlewares"
 	"github.com/bfg-dev/crypto-core/pkg/helpers/cmd"
+	"github.com/bfg-dev/crypto-core/pkg/helpers/server"
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
 	"github.com/bfg-dev/crypto-core/pkg/services"
 	"github.com/codegangsta/negroni"
 	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
 	"github.com/bfg-dev/crypto-core/pkg/api/contributorhandler"
+	"github.com/bfg-dev/crypto-core/pkg/api/healthhandler"
+	"github.com/bfg-dev/crypto-core/pkg/services/adminauth"
+	adminAuthPostgres "github.com/bfg-dev/crypto-core/pkg/services/adminauth/postgres"
+	servicesAmqp "github.com/bfg-dev/crypto-core/pkg/services/amqp"
+	"github.com/bfg-dev/crypto-core/pkg/services/notification"
+	notificationPostgres "github.com/bfg-dev/crypto-core/pkg/services/notification/postgres"
+	cacheMemory "github.com/bfg-dev/crypto-core/pkg/services/cache/memory"
+	"github.com/bfg-dev/crypto-core/pkg/services/leaderboard"
+	leaderboardPostgres "github.com/bfg-dev/crypto-core/pkg/services/leaderboard/postgres"
+	"github.com/bfg-dev/crypto-core/pkg/services/payout"
+	payoutPostgres "github.com/bfg-dev/crypto-core/pkg/services/payout/postgres"
 	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
 	"github.com/bfg-dev/crypto-core/pkg/services/contributor/postgres"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributorauth"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
 )
 
 const defaultConfigName = "config.toml"
 
+// defaultSessionTTL is used when CRYPTO_ADMIN_SESSION_TTL_MIN is not set
+const defaultSessionTTL = 12 * time.Hour
+
+// admin login throttling defaults, used when CRYPTO_ADMIN_LOGIN_* are not set
+const (
+	defaultLoginMaxFailures = 5
+	defaultLoginWindow      = 15 * time.Minute
+)
+
+// healthCheckTimeout limits all readiness checks of one /readyz request
+const healthCheckTimeout = 3 * time.Second
+
+// submission rate limit defaults, used when CRYPTO_SUBMISSION_RATE_* are not set
+const (
+	defaultSubmissionLimit  = 10
+	defaultSubmissionWindow = time.Hour
+)
+
+// statistics reports are cached for defaultStatsCacheTTL unless CRYPTO_STATS_CACHE_TTL_SEC is set
+const defaultStatsCacheTTL = 5 * time.Minute
+
+// notification dispatcher defaults, attempts and base delay can be overridden by config
+const (
+	defaultNotifyPollInterval   = time.Second
+	defaultNotifyBatchSize      = 100
+	defaultNotifyWorkers        = 4
+	defaultNotifyMaxAttempts    = 5
+	defaultNotifyRetryBaseDelay = time.Second
+	defaultNotifyRetryMaxDelay  = 5 * time.Minute
+	defaultNotifyAttemptTimeout = 30 * time.Second
+)
+
 var config string
 
 func main() {
@@ -29,6 +82,23 @@ func main() {
 	err := app.Init(config)
 	cmd.DieIfError(err, "app init error")
 
+	serverConfig, err := server.ReadConfig(app.Config(), server.Config{
+		Addr:           ":8090",
+		ReadTimeout:    10 * time.Second,
+		WriteTimeout:   30 * time.Second,
+		IdleTimeout:    60 * time.Second,
+		DrainTimeout:   15 * time.Second,
+		ShutdownDelay:  5 * time.Second,
+		MaxHeaderBytes: http.DefaultMaxHeaderBytes,
+		MetricsAddr:    ":9090",
+	})
+	cmd.DieIfError(err, "server config error")
+
+	shutdownTracing, err := tracing.Init("contributorsrv",
+		app.Config().GetString("CRYPTO_TRACING_EXPORTER"),
+		app.Config().GetString("CRYPTO_TRACING_ENDPOINT"))
+	cmd.DieIfError(err, "tracing init error")
+
 	/**
 	 *  Init middleware
 	 */
@@ -37,9 +107,17 @@ func main() {
 	errorInterceptorMiddleware, err := middlewares.NewErrorInterceptor(app.Logger())
 	cmd.DieIfError(err, "interceptor middleware init error")
 
+	//Tracing middleware
+	tracingMiddleware, err := middlewares.NewTracing()
+	cmd.DieIfError(err, "tracing middleware init error")
+
+	//Metrics middleware
+	metricsMiddleware, err := middlewares.NewMetrics()
+	cmd.DieIfError(err, "metrics middleware init error")
+
 	//Middleware for all routes
 	//ORDER SENSITIVE!!!
-	common := negroni.New(errorInterceptorMiddleware)
+	common := negroni.New(tracingMiddleware, errorInterceptorMiddleware, metricsMiddleware)
 
 	dbConnection := app.DBConnection()
 	if dbConnection == nil {
@@ -51,33 +129,379 @@ func main() {
 	cmd.DieIfError(err, "NewMissionRepository init error")
 
 	userMissionRepository, err := postgres.NewUserMissionRepository(dbConnection)
+	cmd.DieIfError(err, "NewUserMissionRepository init error")
+
+	notifyDispatcher, err := newNotifyDispatcher(app, dbConnection)
+	cmd.DieIfError(err, "notification dispatcher init error")
+
+	rejectionReasons, err := contributor.ParseRejectionReasons(app.Config().GetString("CRYPTO_REJECTION_REASONS"))
+	cmd.DieIfError(err, "rejection reasons catalogue error")
+
+	submissionLimit := contributor.SubmissionLimit{Count: defaultSubmissionLimit, Window: defaultSubmissionWindow}
+	if count := app.Config().GetInt("CRYPTO_SUBMISSION_RATE_LIMIT"); count > 0 {
+		submissionLimit.Count = count
+	}
+	if windowMin := app.Config().GetInt("CRYPTO_SUBMISSION_RATE_WINDOW_MIN"); windowMin > 0 {
+		submissionLimit.Window = time.Duration(windowMin) * time.Minute
+	}
+
+	namePolicy, err := contributor.ParseDisplayNamePolicy(app.Config().GetString("CRYPTO_DISPLAY_NAME_POLICY"))
+	cmd.DieIfError(err, "display name policy error")
 
 	contributorService, err := contributor.NewService(
 		missionRepository,
 		userMissionRepository,
+		rejectionReasons,
+		submissionLimit,
+		namePolicy,
 	)
-	cmd.DieIfError(err, "cryptofundService init error")
+	cmd.DieIfError(err, "contributorService init error")
+
+	adminRepository, err := adminAuthPostgres.NewAdminRepository(dbConnection)
+	cmd.DieIfError(err, "NewAdminRepository init error")
+
+	sessionRepository, err := adminAuthPostgres.NewSessionRepository(dbConnection)
+	cmd.DieIfError(err, "NewSessionRepository init error")
+
+	sessionTTL := defaultSessionTTL
+	if ttlMin := app.Config().GetInt("CRYPTO_ADMIN_SESSION_TTL_MIN"); ttlMin > 0 {
+		sessionTTL = time.Duration(ttlMin) * time.Minute
+	}
+
+	loginLimit := adminauth.LoginLimit{MaxFailures: defaultLoginMaxFailures, Window: defaultLoginWindow}
+	if maxFailures := app.Config().GetInt("CRYPTO_ADMIN_LOGIN_MAX_FAILURES"); maxFailures > 0 {
+		loginLimit.MaxFailures = maxFailures
+	}
+	if windowMin := app.Config().GetInt("CRYPTO_ADMIN_LOGIN_WINDOW_MIN"); windowMin > 0 {
+		loginLimit.Window = time.Duration(windowMin) * time.Minute
+	}
+
+	authService, err := adminauth.NewService(adminRepository, sessionRepository, sessionTTL, loginLimit)
+	cmd.DieIfError(err, "authService init error")
+
+	payoutRepository, err := payoutPostgres.NewPayoutRepository(dbConnection)
+	cmd.DieIfError(err, "NewPayoutRepository init error")
+
+	payoutService, err := payout.NewService(payoutRepository)
+	cmd.DieIfError(err, "payoutService init error")
+
+	leaderboardRepository, err := leaderboardPostgres.NewLeaderboardRepository(dbConnection)
+	cmd.DieIfError(err, "NewLeaderboardRepository init error")
+
+	statsPeriods, err := leaderboard.ParsePeriods(app.Config().GetString("CRYPTO_STATS_PERIODS"))
+	cmd.DieIfError(err, "statistics periods error")
+
+	statsCacheTTL := defaultStatsCacheTTL
+	if ttlSec := app.Config().GetInt("CRYPTO_STATS_CACHE_TTL_SEC"); ttlSec > 0 {
+		statsCacheTTL = time.Duration(ttlSec) * time.Second
+	}
+
+	statsCache, err := cacheMemory.NewCache(statsCacheTTL)
+	cmd.DieIfError(err, "statsCache init error")
+
+	leaderboardService, err := leaderboard.NewService(app.Logger(), leaderboardRepository, statsCache, statsCacheTTL, statsPeriods, namePolicy)
+	cmd.DieIfError(err, "leaderboardService init error")
+
+	//paid requests are counted as approved, so only moderation and withdrawal change the stats
+	contributorService.OnStatusChanged(leaderboardService.Invalidate)
 
 	handler, err := contributorhandler.New(
 		app,
 		contributorService,
+		authService,
+		payoutService,
+		leaderboardService,
+		serverConfig.TLSEnabled(),
 	)
 	cmd.DieIfError(err, "contributorhandler init error")
 
+	//Admin panel middleware, puts acting admin into request context
+	adminAuthMiddleware, err := middlewares.NewAdminAuth(authService, app.Logger(), "/admin/login")
+	cmd.DieIfError(err, "admin auth middleware init error")
+
+	viewerRoleMiddleware, err := middlewares.NewAdminRole(adminauth.RoleViewer, handler.RenderForbidden)
+	cmd.DieIfError(err, "viewer role middleware init error")
+
+	moderatorRoleMiddleware, err := middlewares.NewAdminRole(adminauth.RoleModerator, handler.RenderForbidden)
+	cmd.DieIfError(err, "moderator role middleware init error")
+
+	adminRoleMiddleware, err := middlewares.NewAdminRole(adminauth.RoleAdmin, handler.RenderForbidden)
+	cmd.DieIfError(err, "admin role middleware init error")
+
+	//Checks csrf token of state changing admin requests
+	csrfMiddleware, err := middlewares.NewCSRF(handler.RenderForbidden)
+	cmd.DieIfError(err, "csrf middleware init error")
+
+	//ORDER SENSITIVE!!!
+	admin := common.With(adminAuthMiddleware, csrfMiddleware)
+
 	r := mux.NewRouter()
 
 	r.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("pkg/templates/contributors/static/"))))
 
-	r.Handle("/getNewUserMissionRequests", common.With(
+	r.Handle("/admin/login", common.With(
+		negroni.WrapFunc(handler.RenderLogin))).Methods("GET")
+
+	r.Handle("/admin/login", common.With(
+		negroni.WrapFunc(handler.Login))).Methods("POST")
+
+	r.Handle("/admin/logout", admin.With(
+		negroni.WrapFunc(handler.Logout))).Methods("POST")
+
+	//contributors list their own requests through /api/submissions
+	r.Handle("/getNewUserMissionRequests", admin.With(viewerRoleMiddleware,
 		negroni.WrapFunc(api.ResponseHandler(handler.GetNewUserMissionRequests)))).Methods("GET")
 
-	r.Handle("/admin/NewUserMissionRequests", common.With(
+	r.Handle("/admin/NewUserMissionRequests", admin.With(viewerRoleMiddleware,
 		negroni.WrapFunc(handler.RenderNewUserMissionRequestList))).Methods("GET")
 
-	r.Handle("/admin/SetUserRequestStatus", common.With(
+	r.Handle("/admin/SetUserRequestStatus", admin.With(moderatorRoleMiddleware,
 		negroni.WrapFunc(handler.SetUserRequestStatus))).Methods("POST")
 
-	http.ListenAndServe(":8090", r)
+	r.Handle("/admin/BulkSetUserRequestStatus", admin.With(moderatorRoleMiddleware,
+		negroni.WrapFunc(handler.BulkSetUserRequestStatus))).Methods("POST")
+
+	r.Handle("/admin/UserRequestStatusHistory", admin.With(viewerRoleMiddleware,
+		negroni.WrapFunc(handler.RenderUserRequestStatusHistory))).Methods("GET")
+
+	r.Handle("/admin/api/missions", admin.With(viewerRoleMiddleware,
+		negroni.WrapFunc(api.ResponseHandler(handler.ListMissions)))).Methods("GET")
+
+	r.Handle("/admin/api/missions", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(api.ResponseHandler(handler.CreateMission)))).Methods("POST")
+
+	r.Handle("/admin/api/missions/{id:[0-9]+}", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(api.ResponseHandler(handler.UpdateMission)))).Methods("PUT")
+
+	r.Handle("/admin/api/missions/{id:[0-9]+}/archive", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(api.ResponseHandler(handler.ArchiveMission)))).Methods("POST")
+
+	r.Handle("/admin/missions", admin.With(viewerRoleMiddleware,
+		negroni.WrapFunc(handler.RenderMissions))).Methods("GET")
+
+	r.Handle("/admin/missions", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(handler.SaveMission))).Methods("POST")
+
+	r.Handle("/admin/missions/new", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(handler.RenderMissionForm))).Methods("GET")
+
+	r.Handle("/admin/missions/{id:[0-9]+}/edit", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(handler.RenderMissionForm))).Methods("GET")
+
+	r.Handle("/admin/missions/{id:[0-9]+}", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(handler.SaveMission))).Methods("POST")
+
+	r.Handle("/admin/missions/{id:[0-9]+}/archive", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(handler.ArchiveMissionForm))).Methods("POST")
+
+	r.Handle("/admin/api/payouts", admin.With(viewerRoleMiddleware,
+		negroni.WrapFunc(api.ResponseHandler(handler.GetPayouts)))).Methods("GET")
+
+	r.Handle("/admin/payouts", admin.With(viewerRoleMiddleware,
+		negroni.WrapFunc(handler.RenderPayouts))).Methods("GET")
+
+	r.Handle("/admin/payouts/batches", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(handler.CreatePayoutBatch))).Methods("POST")
+
+	r.Handle("/admin/payouts/batches/{id:[0-9]+}/export", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(handler.ExportPayoutBatch))).Methods("POST")
+
+	r.Handle("/admin/payouts/batches/{id:[0-9]+}/paid", admin.With(adminRoleMiddleware,
+		negroni.WrapFunc(handler.MarkPayoutBatchPaid))).Methods("POST")
+
+	r.Handle("/leaderboard", common.With(
+		negroni.WrapFunc(api.ResponseHandler(handler.GetPublicLeaderboard)))).Methods("GET")
+
+	r.Handle("/admin/api/stats/leaderboard", admin.With(viewerRoleMiddleware,
+		negroni.WrapFunc(api.ResponseHandler(handler.GetLeaderboard)))).Methods("GET")
+
+	r.Handle("/admin/api/stats/missions", admin.With(viewerRoleMiddleware,
+		negroni.WrapFunc(api.ResponseHandler(handler.GetMissionStats)))).Methods("GET")
+
+	r.Handle("/admin/stats/leaderboard", admin.With(viewerRoleMiddleware,
+		negroni.WrapFunc(handler.RenderLeaderboard))).Methods("GET")
+
+	r.Handle("/admin/stats/missions", admin.With(viewerRoleMiddleware,
+		negroni.WrapFunc(handler.RenderMissionStats))).Methods("GET")
+
+	//Contributor api is served only when platform shares token secret with us
+	if secret := app.Config().GetString("CRYPTO_CONTRIBUTOR_TOKEN_SECRET"); secret != "" {
+		contributorAuthService, err := contributorauth.NewService(secret)
+		cmd.DieIfError(err, "contributorAuthService init error")
+
+		contributorAuthMiddleware, err := middlewares.NewContributorAuth(contributorAuthService)
+		cmd.DieIfError(err, "contributor auth middleware init error")
+
+		contributorAPI := common.With(contributorAuthMiddleware)
+
+		r.Handle("/api/missions", contributorAPI.With(
+			negroni.WrapFunc(api.ResponseHandler(handler.ListActiveMissions)))).Methods("GET")
+
+		r.Handle("/api/submissions", contributorAPI.With(
+			negroni.WrapFunc(api.ResponseHandler(handler.ListSubmissions)))).Methods("GET")
+
+		r.Handle("/api/submissions", contributorAPI.With(
+			negroni.WrapFunc(api.ResponseHandler(handler.SubmitMission)))).Methods("POST")
+
+		r.Handle("/api/submissions/{id:[0-9]+}", contributorAPI.With(
+			negroni.WrapFunc(api.ResponseHandler(handler.GetSubmission)))).Methods("GET")
+
+		r.Handle("/api/submissions/{id:[0-9]+}/withdraw", contributorAPI.With(
+			negroni.WrapFunc(api.ResponseHandler(handler.WithdrawSubmission)))).Methods("POST")
+
+		r.Handle("/api/leaderboard/opt-out", contributorAPI.With(
+			negroni.WrapFunc(api.ResponseHandler(handler.GetLeaderboardOptOut)))).Methods("GET")
+
+		r.Handle("/api/leaderboard/opt-out", contributorAPI.With(
+			negroni.WrapFunc(api.ResponseHandler(handler.OptOutOfLeaderboard)))).Methods("POST")
+
+		r.Handle("/api/leaderboard/opt-out", contributorAPI.With(
+			negroni.WrapFunc(api.ResponseHandler(handler.OptInToLeaderboard)))).Methods("DELETE")
+	} else {
+		app.Logger().Warn("CRYPTO_CONTRIBUTOR_TOKEN_SECRET is not set, contributor api is disabled")
+	}
+
+	healthChecks := map[string]healthhandler.Checker{
+		"db": healthhandler.DBChecker(dbConnection),
+	}
+	if app.Config().GetString("CRYPTO_NOTIFY_AMQP_EXCHANGE") != "" {
+		amqpAddr := net.JoinHostPort(app.Config().GetString("CRYPTO_AMQP_HOST"), strconv.Itoa(app.Config().GetInt("CRYPTO_AMQP_PORT")))
+		healthChecks["amqp"] = healthhandler.TCPChecker(amqpAddr)
+	}
+	if url := app.Config().GetString("CRYPTO_INDEXES_URL"); url != "" {
+		healthChecks["cryptofund"] = healthhandler.HTTPChecker(url)
+	}
+
+	healthHandler, err := healthhandler.New(app, healthChecks, healthCheckTimeout)
+	cmd.DieIfError(err, "healthhandler init error")
+
+	r.HandleFunc("/healthz", healthHandler.Healthz).Methods("GET")
+	r.HandleFunc("/readyz", healthHandler.Readyz).Methods("GET")
+
+	metricsServer := metrics.NewServer(serverConfig.MetricsAddr)
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != http.ErrServerClosed {
+			app.Logger().Error("metrics server stopped", zap.Error(err))
+		}
+	}()
+
+	err = server.Run(serverConfig.NewServer(r), serverConfig, app.Logger(), healthHandler.Shutdown)
+	cmd.DieIfError(err, "http server error")
+
+	if err := metricsServer.Close(); err != nil {
+		app.Logger().Error("unable to close metrics server", zap.Error(err))
+	}
+
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), serverConfig.DrainTimeout)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		app.Logger().Error("unable to flush traces", zap.Error(err))
+	}
+	cancelTracing()
+
+	notifyCtx, cancelNotify := context.WithTimeout(context.Background(), serverConfig.DrainTimeout)
+	if err := notifyDispatcher.Close(notifyCtx); err != nil {
+		app.Logger().Error("unable to flush notifications", zap.Error(err))
+	}
+	cancelNotify()
+
+	if err := statsCache.Close(); err != nil {
+		app.Logger().Error("unable to close statistics cache", zap.Error(err))
+	}
+
+	if err := dbConnection.Close(); err != nil {
+		app.Logger().Error("unable to close db connection", zap.Error(err))
+	}
+}
+
+// newNotifyDispatcher builds deliverers of every configured channel,
+// channel is enabled when its address key is set
+func newNotifyDispatcher(app services.App, dbConnection *sqlx.DB) (*notification.Dispatcher, error) {
+	config := app.Config()
+	deliverers := make([]notification.Deliverer, 0)
+
+	if addr := config.GetString("CRYPTO_NOTIFY_SMTP_ADDR"); addr != "" {
+		deliverer, err := notification.NewSMTPDeliverer(addr,
+			config.GetString("CRYPTO_NOTIFY_SMTP_FROM"),
+			config.GetString("CRYPTO_NOTIFY_SMTP_USER"),
+			config.GetString("CRYPTO_NOTIFY_SMTP_PASSWORD"))
+		if err != nil {
+			return nil, err
+		}
+		deliverers = append(deliverers, deliverer)
+	}
+
+	if target := config.GetString("CRYPTO_NOTIFY_WEBHOOK_URL"); target != "" {
+		deliverer, err := notification.NewWebhookDeliverer(target, config.GetString("CRYPTO_NOTIFY_WEBHOOK_SECRET"))
+		if err != nil {
+			return nil, err
+		}
+		deliverers = append(deliverers, deliverer)
+	}
+
+	if exchange := config.GetString("CRYPTO_NOTIFY_AMQP_EXCHANGE"); exchange != "" {
+		amqpConnectionProvider, err := servicesAmqp.NewConnectionProvider(config.GetString("CRYPTO_AMQP_HOST"),
+			config.GetInt("CRYPTO_AMQP_PORT"),
+			config.GetString("CRYPTO_AMQP_USER"),
+			config.GetString("CRYPTO_AMQP_PASS"),
+			config.GetString("CRYPTO_AMQP_VHOST"),
+			app.Logger())
+		if err != nil {
+			return nil, err
+		}
+
+		deliverer, err := notification.NewAMQPDeliverer(amqpConnectionProvider, exchange)
+		if err != nil {
+			return nil, err
+		}
+		deliverers = append(deliverers, deliverer)
+	}
+
+	templates, err := notification.NewTemplates(config.GetString("CRYPTO_NOTIFY_TEMPLATES_DIR"))
+	if err != nil {
+		return nil, err
+	}
+
+	recipientRepository, err := notificationPostgres.NewRecipientRepository(dbConnection)
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetterRepository, err := notificationPostgres.NewDeadLetterRepository(dbConnection)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxRepository, err := notificationPostgres.NewOutboxRepository(dbConnection)
+	if err != nil {
+		return nil, err
+	}
+
+	dispatcherConfig := notification.DispatcherConfig{
+		PollInterval:   defaultNotifyPollInterval,
+		BatchSize:      defaultNotifyBatchSize,
+		Workers:        defaultNotifyWorkers,
+		MaxAttempts:    defaultNotifyMaxAttempts,
+		RetryBaseDelay: defaultNotifyRetryBaseDelay,
+		RetryMaxDelay:  defaultNotifyRetryMaxDelay,
+		AttemptTimeout: defaultNotifyAttemptTimeout,
+	}
+	if attempts := config.GetInt("CRYPTO_NOTIFY_MAX_ATTEMPTS"); attempts > 0 {
+		dispatcherConfig.MaxAttempts = attempts
+	}
+	if baseMs := config.GetInt("CRYPTO_NOTIFY_RETRY_BASE_MS"); baseMs > 0 {
+		dispatcherConfig.RetryBaseDelay = time.Duration(baseMs) * time.Millisecond
+		if dispatcherConfig.RetryMaxDelay < dispatcherConfig.RetryBaseDelay {
+			dispatcherConfig.RetryMaxDelay = dispatcherConfig.RetryBaseDelay
+		}
+	}
+
+	dispatcher, err := notification.NewDispatcher(app.Logger(), outboxRepository, recipientRepository,
+		deadLetterRepository, templates, deliverers, dispatcherConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return dispatcher, nil
 }
 
 func init() {
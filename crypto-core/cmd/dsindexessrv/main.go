@@ -1,17 +1,23 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
+	"time"
 
-	"github.com/bfg-dev/crypto-core/pkg/api"
 	"github.com/bfg-dev/crypto-core/pkg/api/dsindexeshandler"
+	"github.com/bfg-dev/crypto-core/pkg/api/healthhandler"
 	"github.com/bfg-dev/crypto-core/pkg/api/middlewares"
 	"github.com/bfg-dev/crypto-core/pkg/helpers/cmd"
+	"github.com/bfg-dev/crypto-core/pkg/helpers/server"
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
 	"github.com/bfg-dev/crypto-core/pkg/services"
 	servicesAmqp "github.com/bfg-dev/crypto-core/pkg/services/amqp"
 	"github.com/bfg-dev/crypto-core/pkg/services/asset"
@@ -19,18 +25,30 @@ import (
 	"github.com/bfg-dev/crypto-core/pkg/services/blockchain"
 	"github.com/bfg-dev/crypto-core/pkg/services/blockchain/amqp/buyback"
 	blockchainPostgres "github.com/bfg-dev/crypto-core/pkg/services/blockchain/postgres"
+	cacheMemory "github.com/bfg-dev/crypto-core/pkg/services/cache/memory"
 	"github.com/bfg-dev/crypto-core/pkg/services/cryptofund"
 	"github.com/bfg-dev/crypto-core/pkg/services/tokenemission"
 	tokenEmissionPostgres "github.com/bfg-dev/crypto-core/pkg/services/tokenemission/postgres"
 	"github.com/bfg-dev/crypto-core/pkg/services/tokenredemption"
 	tokenRedemptionPostgres "github.com/bfg-dev/crypto-core/pkg/services/tokenredemption/postgres"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
 	"github.com/bfg-dev/crypto-core/pkg/types/exchange"
 	"github.com/codegangsta/negroni"
 	"github.com/gorilla/mux"
+	"go.uber.org/zap"
 )
 
 const defaultConfigName = "config.toml"
 
+// healthCheckTimeout limits all readiness checks of one /readyz request
+const healthCheckTimeout = 3 * time.Second
+
+// defaultSummaryTimeout is used when CRYPTO_SUMMARY_TIMEOUT_MS is not set
+const defaultSummaryTimeout = 5 * time.Second
+
+// defaultSummaryCacheTTL is used when CRYPTO_SUMMARY_CACHE_TTL_SEC is not set
+const defaultSummaryCacheTTL = 30 * time.Second
+
 var config string
 
 func main() {
@@ -39,6 +57,23 @@ func main() {
 	err := app.Init(config)
 	cmd.DieIfError(err, "app init error")
 
+	serverConfig, err := server.ReadConfig(app.Config(), server.Config{
+		Addr:           ":8087",
+		ReadTimeout:    10 * time.Second,
+		WriteTimeout:   30 * time.Second,
+		IdleTimeout:    60 * time.Second,
+		DrainTimeout:   15 * time.Second,
+		ShutdownDelay:  5 * time.Second,
+		MaxHeaderBytes: http.DefaultMaxHeaderBytes,
+		MetricsAddr:    ":9087",
+	})
+	cmd.DieIfError(err, "server config error")
+
+	shutdownTracing, err := tracing.Init("dsindexessrv",
+		app.Config().GetString("CRYPTO_TRACING_EXPORTER"),
+		app.Config().GetString("CRYPTO_TRACING_ENDPOINT"))
+	cmd.DieIfError(err, "tracing init error")
+
 	amqpConnectionProvider, err := servicesAmqp.NewConnectionProvider(app.Config().GetString("CRYPTO_AMQP_HOST"),
 		app.Config().GetInt("CRYPTO_AMQP_PORT"),
 		app.Config().GetString("CRYPTO_AMQP_USER"),
@@ -55,9 +90,17 @@ func main() {
 	errorInterceptorMiddleware, err := middlewares.NewErrorInterceptor(app.Logger())
 	cmd.DieIfError(err, "interceptor middleware init error")
 
+	//Tracing middleware
+	tracingMiddleware, err := middlewares.NewTracing()
+	cmd.DieIfError(err, "tracing middleware init error")
+
+	//Metrics middleware
+	metricsMiddleware, err := middlewares.NewMetrics()
+	cmd.DieIfError(err, "metrics middleware init error")
+
 	//Middleware for all routes
 	//ORDER SENSITIVE!!!
-	common := negroni.New(errorInterceptorMiddleware)
+	common := negroni.New(tracingMiddleware, errorInterceptorMiddleware, metricsMiddleware)
 
 	dbConnection := app.DBConnection()
 	if dbConnection == nil {
@@ -102,6 +145,9 @@ func main() {
 	buybackOracleClient, err := buyback.NewOracleClient(exchange.Middleware, amqpConnectionProvider)
 	cmd.DieIfError(err, "NewOracleClient init error")
 
+	buybackOracleClient, err = blockchain.NewMetricsOracleClient(buybackOracleClient)
+	cmd.DieIfError(err, "NewMetricsOracleClient init error")
+
 	buybackService, err := blockchain.NewBuybackService(buybackRepository, buybackEntryRepository, buybackPriceRepo, buybackOracleClient, app.Logger())
 	cmd.DieIfError(err, "NewBuybackService init error")
 
@@ -112,24 +158,85 @@ func main() {
 	)
 	cmd.DieIfError(err, "cryptofundService init error")
 
+	cryptofundService, err = cryptofund.NewMetricsService(cryptofundService)
+	cmd.DieIfError(err, "cryptofund metrics service init error")
+
+	summaryTimeout := defaultSummaryTimeout
+	if timeoutMs := app.Config().GetInt("CRYPTO_SUMMARY_TIMEOUT_MS"); timeoutMs > 0 {
+		summaryTimeout = time.Duration(timeoutMs) * time.Millisecond
+	}
+
+	summaryCacheTTL := defaultSummaryCacheTTL
+	if ttlSec := app.Config().GetInt("CRYPTO_SUMMARY_CACHE_TTL_SEC"); ttlSec > 0 {
+		summaryCacheTTL = time.Duration(ttlSec) * time.Second
+	}
+
+	summaryCache, err := cacheMemory.NewCache(summaryCacheTTL)
+	cmd.DieIfError(err, "summaryCache init error")
+
 	handler, err := dsindexeshandler.New(
 		app,
 		assetService,
 		tokenEmissionService,
 		tokenRedemptionService,
 		buybackService,
-		cryptofundService)
+		cryptofundService,
+		summaryTimeout,
+		summaryCache,
+		summaryCacheTTL)
 	cmd.DieIfError(err, "dsindexeshandler init error")
 
 	r := mux.NewRouter()
 
 	r.Handle("/1.0/tokens/summary", common.With(
-		negroni.WrapFunc(api.ResponseHandler(handler.GetSummary)))).Methods("GET")
+		negroni.WrapFunc(dsindexeshandler.ResponseHandler(handler.GetSummary)))).Methods("GET")
 
 	r.Handle("/1.1/tokens/summary", common.With(
-		negroni.WrapFunc(api.ResponseHandler(handler.GetSummaryV2)))).Methods("GET")
+		negroni.WrapFunc(dsindexeshandler.ResponseHandler(handler.GetSummaryV2)))).Methods("GET")
 
-	http.ListenAndServe(":8087", r)
+	r.Handle("/1.2/tokens/summaries", common.With(
+		negroni.WrapFunc(dsindexeshandler.ResponseHandler(handler.GetSummaries)))).Methods("GET")
+
+	//connection provider does not report its state, the broker itself is checked
+	amqpAddr := net.JoinHostPort(app.Config().GetString("CRYPTO_AMQP_HOST"), strconv.Itoa(app.Config().GetInt("CRYPTO_AMQP_PORT")))
+
+	healthHandler, err := healthhandler.New(app, map[string]healthhandler.Checker{
+		"db":         healthhandler.DBChecker(dbConnection),
+		"amqp":       healthhandler.TCPChecker(amqpAddr),
+		"cryptofund": healthhandler.HTTPChecker(app.Config().GetString("CRYPTO_INDEXES_URL")),
+	}, healthCheckTimeout)
+	cmd.DieIfError(err, "healthhandler init error")
+
+	r.HandleFunc("/healthz", healthHandler.Healthz).Methods("GET")
+	r.HandleFunc("/readyz", healthHandler.Readyz).Methods("GET")
+
+	metricsServer := metrics.NewServer(serverConfig.MetricsAddr)
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != http.ErrServerClosed {
+			app.Logger().Error("metrics server stopped", zap.Error(err))
+		}
+	}()
+
+	err = server.Run(serverConfig.NewServer(r), serverConfig, app.Logger(), healthHandler.Shutdown)
+	cmd.DieIfError(err, "http server error")
+
+	if err := metricsServer.Close(); err != nil {
+		app.Logger().Error("unable to close metrics server", zap.Error(err))
+	}
+
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), serverConfig.DrainTimeout)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		app.Logger().Error("unable to flush traces", zap.Error(err))
+	}
+	cancelTracing()
+
+	if err := summaryCache.Close(); err != nil {
+		app.Logger().Error("unable to close summary cache", zap.Error(err))
+	}
+
+	if err := dbConnection.Close(); err != nil {
+		app.Logger().Error("unable to close db connection", zap.Error(err))
+	}
 }
 
 func init() {
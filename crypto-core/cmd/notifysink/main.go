@@ -0,0 +1,43 @@
+// notifysink runs local SMTP server and webhook receiver which print every
+// notification they get, point CRYPTO_NOTIFY_SMTP_ADDR and CRYPTO_NOTIFY_WEBHOOK_URL to it.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/bfg-dev/crypto-core/pkg/services/notification/sink"
+)
+
+func main() {
+	smtpAddr := flag.String("smtp", "127.0.0.1:2525", "smtp listen address")
+	httpAddr := flag.String("http", "127.0.0.1:8025", "webhook listen address")
+	failRequests := flag.Int("fail", 0, "number of first webhook requests answered with 503")
+	flag.Parse()
+
+	s := &sink.Sink{
+		FailRequests: *failRequests,
+		OnMail: func(m sink.Mail) {
+			log.Printf("mail from %s to %v:\n%s", m.From, m.To, m.Data)
+		},
+		OnRequest: func(r sink.Request) {
+			log.Printf("webhook event %s:\n%s", r.Header.Get("X-Event-Id"), r.Body)
+		},
+	}
+
+	l, err := net.Listen("tcp", *smtpAddr)
+	if err != nil {
+		log.Fatalf("unable to listen smtp: %v", err)
+	}
+
+	go func() {
+		if err := s.ServeSMTP(l); err != nil {
+			log.Fatalf("smtp stopped: %v", err)
+		}
+	}()
+
+	log.Printf("smtp on %s, webhook on http://%s/", *smtpAddr, *httpAddr)
+	log.Fatal(http.ListenAndServe(*httpAddr, s))
+}
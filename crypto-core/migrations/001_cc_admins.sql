@@ -0,0 +1,19 @@
+-- Contributor admin panel accounts. Every admin is a row of "users",
+-- passwordHash is a bcrypt hash (e.g. `htpasswd -bnBC 10 "" password | tr -d ':\n'`)
+CREATE TABLE "ccAdmins" (
+  "id"           BIGSERIAL PRIMARY KEY,
+  "userId"       BIGINT       NOT NULL UNIQUE REFERENCES "users" ("id"),
+  "role"         VARCHAR(32)  NOT NULL CHECK ("role" IN ('viewer', 'moderator', 'admin')),
+  "passwordHash" VARCHAR(255) NOT NULL,
+  "createdAt"    TIMESTAMP    NOT NULL DEFAULT now()
+);
+
+-- Admin sessions, "id" is sha256 of the token stored in the session cookie
+CREATE TABLE "ccAdminSessions" (
+  "id"        VARCHAR(64) PRIMARY KEY,
+  "adminId"   BIGINT      NOT NULL REFERENCES "ccAdmins" ("id") ON DELETE CASCADE,
+  "createdAt" TIMESTAMP   NOT NULL DEFAULT now(),
+  "expiresAt" TIMESTAMP   NOT NULL
+);
+
+CREATE INDEX "ccAdminSessions_expiresAt_idx" ON "ccAdminSessions" ("expiresAt");
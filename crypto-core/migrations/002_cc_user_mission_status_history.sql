@@ -0,0 +1,12 @@
+-- Every status change of a mission request, written in the same transaction as the change
+CREATE TABLE "ccUserMissionStatusHistory" (
+  "id"            BIGSERIAL PRIMARY KEY,
+  "userMissionId" BIGINT      NOT NULL REFERENCES "ccUserMissions" ("id") ON DELETE CASCADE,
+  "actorId"       BIGINT      NOT NULL REFERENCES "ccAdmins" ("id"),
+  "oldStatus"     VARCHAR(32) NOT NULL,
+  "newStatus"     VARCHAR(32) NOT NULL,
+  "comment"       TEXT        NOT NULL DEFAULT '',
+  "createdAt"     TIMESTAMP   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX "ccUserMissionStatusHistory_userMissionId_idx" ON "ccUserMissionStatusHistory" ("userMissionId", "createdAt");
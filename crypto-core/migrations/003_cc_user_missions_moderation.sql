@@ -0,0 +1,4 @@
+-- Moderation result of a mission request, visible to the contributor
+ALTER TABLE "ccUserMissions"
+  ADD COLUMN "rejectionReason"  VARCHAR(64) NOT NULL DEFAULT '',
+  ADD COLUMN "moderatorComment" TEXT        NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Keyset pagination of mission requests list, filtered by status and ordered by (createdAt, id)
+CREATE INDEX "ccUserMissions_status_createdAt_id_idx" ON "ccUserMissions" ("status", "createdAt", "id");
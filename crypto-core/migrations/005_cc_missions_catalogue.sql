@@ -0,0 +1,9 @@
+-- Mission catalogue fields managed from the admin panel
+ALTER TABLE "ccMissions"
+  ADD COLUMN "description"        TEXT           NOT NULL DEFAULT '',
+  ADD COLUMN "reward"             NUMERIC(36, 18) NOT NULL DEFAULT 0,
+  ADD COLUMN "requiredParameters" TEXT           NOT NULL DEFAULT '[]',
+  ADD COLUMN "activeFrom"         TIMESTAMP      NULL,
+  ADD COLUMN "activeTo"           TIMESTAMP      NULL,
+  ADD COLUMN "archivedAt"         TIMESTAMP      NULL,
+  ADD COLUMN "updatedAt"          TIMESTAMP      NOT NULL DEFAULT now();
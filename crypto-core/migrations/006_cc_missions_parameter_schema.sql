@@ -0,0 +1,10 @@
+-- Typed parameter schema replaces the list of required parameter names,
+-- existing parameters were always rendered as links, so they become required urls
+ALTER TABLE "ccMissions" ADD COLUMN "parameterSchema" TEXT NOT NULL DEFAULT '[]';
+
+UPDATE "ccMissions" SET "parameterSchema" = COALESCE((
+  SELECT json_agg(json_build_object('name', p.name, 'type', 'url', 'required', true))::text
+  FROM json_array_elements_text("requiredParameters"::json) AS p(name)
+), '[]');
+
+ALTER TABLE "ccMissions" DROP COLUMN "requiredParameters";
@@ -0,0 +1,26 @@
+-- Reward payout ledger, amounts are in ATx units like token emission ledgers
+CREATE TABLE "ccPayoutBatches" (
+  "id"         BIGSERIAL PRIMARY KEY,
+  "status"     VARCHAR(16)  NOT NULL DEFAULT 'open',
+  "createdBy"  BIGINT       NOT NULL REFERENCES "ccAdmins" ("id"),
+  "paidBy"     BIGINT       NULL REFERENCES "ccAdmins" ("id"),
+  "reference"  VARCHAR(255) NOT NULL DEFAULT '',
+  "createdAt"  TIMESTAMP    NOT NULL DEFAULT now(),
+  "exportedAt" TIMESTAMP    NULL,
+  "paidAt"     TIMESTAMP    NULL
+);
+
+CREATE TABLE "ccPayouts" (
+  "id"            BIGSERIAL PRIMARY KEY,
+  "userMissionId" BIGINT         NOT NULL UNIQUE REFERENCES "ccUserMissions" ("id"),
+  "userId"        BIGINT         NOT NULL REFERENCES "users" ("id"),
+  "missionId"     BIGINT         NOT NULL REFERENCES "ccMissions" ("id"),
+  "amount"        NUMERIC(78, 0) NOT NULL CHECK ("amount" >= 0),
+  "status"        VARCHAR(16)    NOT NULL DEFAULT 'pending',
+  "batchId"       BIGINT         NULL REFERENCES "ccPayoutBatches" ("id"),
+  "createdAt"     TIMESTAMP      NOT NULL DEFAULT now(),
+  "paidAt"        TIMESTAMP      NULL
+);
+
+CREATE INDEX "ccPayouts_status_idx" ON "ccPayouts" ("status", "id");
+CREATE INDEX "ccPayouts_batchId_idx" ON "ccPayouts" ("batchId");
@@ -0,0 +1,12 @@
+-- Notifications not delivered after all retries, payload is the rendered message as json
+CREATE TABLE "ccNotificationDeadLetters" (
+  "id"        BIGSERIAL PRIMARY KEY,
+  "eventId"   VARCHAR(64) NOT NULL,
+  "channel"   VARCHAR(32) NOT NULL,
+  "payload"   TEXT        NOT NULL,
+  "error"     TEXT        NOT NULL,
+  "attempts"  INT         NOT NULL,
+  "createdAt" TIMESTAMP   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX "ccNotificationDeadLetters_createdAt_idx" ON "ccNotificationDeadLetters" ("createdAt");
@@ -0,0 +1,6 @@
+-- Contributors submit and withdraw their own requests. Withdrawal is written to status history
+-- without actor, admin actors keep referencing "ccAdmins"
+ALTER TABLE "ccUserMissionStatusHistory" ALTER COLUMN "actorId" DROP NOT NULL;
+
+-- Submissions of one contributor: rate limit window and duplicate check
+CREATE INDEX "ccUserMissions_userId_createdAt_idx" ON "ccUserMissions" ("userId", "createdAt");
@@ -0,0 +1,5 @@
+-- Contributors hidden from public leaderboard, admin statistics still include them
+CREATE TABLE "ccLeaderboardOptOuts" (
+  "userId"    BIGINT    PRIMARY KEY REFERENCES "users" ("id"),
+  "createdAt" TIMESTAMP NOT NULL DEFAULT now()
+);
@@ -0,0 +1,13 @@
+-- Notification events written in the transaction of the status change, the dispatcher deletes
+-- them once delivered. "done" lists channels which need no more attempts, separated by commas
+CREATE TABLE "ccNotificationOutbox" (
+  "id"            BIGSERIAL   PRIMARY KEY,
+  "eventId"       VARCHAR(64) NOT NULL,
+  "payload"       TEXT        NOT NULL,
+  "done"          TEXT        NOT NULL DEFAULT '',
+  "attempts"      INT         NOT NULL DEFAULT 0,
+  "nextAttemptAt" TIMESTAMP   NOT NULL DEFAULT now(),
+  "createdAt"     TIMESTAMP   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX "ccNotificationOutbox_nextAttemptAt_idx" ON "ccNotificationOutbox" ("nextAttemptAt");
@@ -0,0 +1,170 @@
+package contributorhandler
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/bfg-dev/crypto-core/pkg/api/middlewares"
+	"github.com/bfg-dev/crypto-core/pkg/services/adminauth"
+	"github.com/bfg-dev/crypto-core/pkg/templates/contributors"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	loginPath = "/admin/login"
+	homePath  = "/admin/NewUserMissionRequests"
+
+	// loginCSRFCookie keeps csrf secret of the login form, there is no session to derive the token from yet
+	loginCSRFCookie = "admin_login_csrf"
+)
+
+// csrfToken returns csrf token of the admin session of req
+func (h *ContributorHandler) csrfToken(req *http.Request) string {
+	cookie, err := req.Cookie(middlewares.AdminSessionCookie)
+	if err != nil {
+		return ""
+	}
+	return adminauth.CSRFToken(cookie.Value)
+}
+
+// RenderForbidden is used by role and csrf middlewares to reject requests
+func (h *ContributorHandler) RenderForbidden(w http.ResponseWriter, req *http.Request) {
+	w.WriteHeader(http.StatusForbidden)
+	contributors.WritePageTemplate(w, &contributors.ForbiddenPage{
+		Message: "Недостаточно прав для этого действия, либо форма устарела. Обновите страницу и попробуйте снова.",
+	})
+}
+
+// loginCSRFSecret returns csrf secret of the login form, new secret is set to the cookie when there is none
+func (h *ContributorHandler) loginCSRFSecret(w http.ResponseWriter, req *http.Request) (string, error) {
+	if cookie, err := req.Cookie(loginCSRFCookie); err == nil && cookie.Value != "" {
+		return cookie.Value, nil
+	}
+
+	secret, err := adminauth.NewLoginCSRFSecret()
+	if err != nil {
+		return "", err
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     loginCSRFCookie,
+		Value:    secret,
+		Path:     loginPath,
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	return secret, nil
+}
+
+// clientIP returns address of the client without port, the server is not behind a proxy
+func clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+func (h *ContributorHandler) RenderLogin(w http.ResponseWriter, req *http.Request) {
+	secret, err := h.loginCSRFSecret(w, req)
+	if err != nil {
+		h.app.Logger().Error("unable to generate login csrf secret", zap.Error(err))
+		http.Error(w, "unable to render login form", http.StatusInternalServerError)
+		return
+	}
+
+	contributors.WritePageTemplate(w, &contributors.LoginPage{
+		BasePage: contributors.BasePage{CSRFToken: adminauth.CSRFToken(secret)},
+	})
+}
+
+func (h *ContributorHandler) Login(w http.ResponseWriter, req *http.Request) {
+
+	if err := req.ParseForm(); err != nil {
+		w.Write([]byte("cannot parse form"))
+		return
+	}
+
+	cookie, err := req.Cookie(loginCSRFCookie)
+	if err != nil || !adminauth.ValidCSRFToken(cookie.Value, req.PostFormValue(adminauth.CSRFFormField)) {
+		h.RenderForbidden(w, req)
+		return
+	}
+
+	email := req.FormValue("email")
+
+	ctx, span := tracing.StartSpan(req.Context(), "authService.Login")
+	token, admin, err := h.authService.Login(ctx, email, req.FormValue("password"), clientIP(req))
+	tracing.EndSpan(span, err)
+	if err != nil {
+		p := &contributors.LoginPage{
+			BasePage: contributors.BasePage{CSRFToken: adminauth.CSRFToken(cookie.Value)},
+			Email:    email,
+			Error:    "Неверный email или пароль",
+		}
+		status := http.StatusUnauthorized
+
+		var tooManyAttempts *adminauth.TooManyAttemptsError
+		switch {
+		case errors.As(err, &tooManyAttempts):
+			h.app.Logger().Warn("admin login throttled", zap.String("email", email), zap.String("clientIP", clientIP(req)))
+			p.Error = "Слишком много неудачных попыток входа, попробуйте позже"
+			status = http.StatusTooManyRequests
+		case errors.Cause(err) != adminauth.ErrInvalidCredentials:
+			h.app.Logger().Error("unable to login admin", zap.Error(err))
+			p.Error = "Не удалось войти, попробуйте позже"
+		}
+
+		w.WriteHeader(status)
+		contributors.WritePageTemplate(w, p)
+		return
+	}
+
+	h.app.Logger().Info("admin logged in", zap.Int64("adminID", admin.ID), zap.String("role", string(admin.Role)))
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     middlewares.AdminSessionCookie,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     loginCSRFCookie,
+		Value:    "",
+		Path:     loginPath,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	http.Redirect(w, req, homePath, http.StatusSeeOther)
+}
+
+func (h *ContributorHandler) Logout(w http.ResponseWriter, req *http.Request) {
+
+	if cookie, err := req.Cookie(middlewares.AdminSessionCookie); err == nil {
+		if err = h.authService.Logout(req.Context(), cookie.Value); err != nil {
+			h.app.Logger().Error("unable to logout admin", zap.Error(err))
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     middlewares.AdminSessionCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, req, loginPath, http.StatusSeeOther)
+}
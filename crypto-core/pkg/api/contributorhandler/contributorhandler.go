@@ -5,24 +5,36 @@ import (
 	"github.com/bfg-dev/crypto-core/pkg/api"
 	"github.com/bfg-dev/crypto-core/pkg/services"
 	"github.com/pkg/errors"
+	"github.com/bfg-dev/crypto-core/pkg/services/adminauth"
 	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/bfg-dev/crypto-core/pkg/services/leaderboard"
+	"github.com/bfg-dev/crypto-core/pkg/services/payout"
 	"go.uber.org/zap"
 	"github.com/bfg-dev/crypto-core/pkg/bfgerrors"
 	"github.com/bfg-dev/crypto-core/pkg/templates/contributors"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
 	"strconv"
 	"github.com/bfg-dev/crypto-core/pkg/entities"
 	"strings"
-	"fmt"
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
 )
 
 type ContributorHandler struct {
 	app                services.App
 	contributorService contributor.Service
+	authService        adminauth.Service
+	payoutService      payout.Service
+	leaderboardService leaderboard.Service
+	secureCookies      bool
 }
 
 func New(
 	application services.App,
 	contributorService contributor.Service,
+	authService adminauth.Service,
+	payoutService payout.Service,
+	leaderboardService leaderboard.Service,
+	secureCookies bool,
 ) (*ContributorHandler, error) {
 
 	if application == nil {
@@ -30,51 +42,121 @@ func New(
 	}
 
 	if contributorService == nil {
-		return nil, errors.New("ContributorHandler.New, tokenemission cannot be empty")
+		return nil, errors.New("ContributorHandler.New, contributorService cannot be empty")
+	}
+
+	if authService == nil {
+		return nil, errors.New("ContributorHandler.New, authService cannot be empty")
+	}
+
+	if payoutService == nil {
+		return nil, errors.New("ContributorHandler.New, payoutService cannot be empty")
+	}
+
+	if leaderboardService == nil {
+		return nil, errors.New("ContributorHandler.New, leaderboardService cannot be empty")
 	}
 
 	return &ContributorHandler{
-		app:                    application,
+		app:                application,
 		contributorService: contributorService,
+		authService:        authService,
+		payoutService:      payoutService,
+		leaderboardService: leaderboardService,
+		secureCookies:      secureCookies,
 	}, nil
 }
 
+// GetNewUserMissionRequests lists mission requests, new ones by default. Requests of one user (userId)
+// are listed with every status by default, so rejected ones are shown with their reasons.
+// See parseListFilter for parameters.
 func (h *ContributorHandler) GetNewUserMissionRequests(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
 
-	requests, err := h.contributorService.GetNewMissionRequests()
+	query := req.URL.Query()
+	if _, ok := query["status"]; !ok && query.Get("userId") != "" {
+		query.Set("status", anyStatus)
+	}
+
+	filter, err := parseListFilter(query)
 	if err != nil {
-		h.app.Logger().Error("unable to get new missions request", zap.Error(err))
-		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get new missions request", nil)
+		return api.ErrorResponse(err.Error()), nil
 	}
+	filter.MaskEmails = maskEmails(req)
 
-	return api.SuccessResponse(requests), nil
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.ListMissionRequests")
+	page, err := h.contributorService.ListMissionRequests(ctx, filter)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get missions requests", zap.Error(err))
+		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get missions requests", nil)
+	}
+	h.logParametersErrors(page.Requests...)
+
+	return api.SuccessResponse(page), nil
 }
 
 func (h *ContributorHandler) RenderNewUserMissionRequestList(w http.ResponseWriter, req *http.Request) {
 
-	requests, err := h.contributorService.GetNewMissionRequestsList()
+	p := &contributors.NewRequestsListPage{
+		BasePage: contributors.BasePage{CSRFToken: h.csrfToken(req)},
+		Query:    req.URL.Query(),
+		Reasons:  h.contributorService.RejectionReasons(),
+	}
+
+	filter, err := parseListFilter(req.URL.Query())
 	if err != nil {
-		h.app.Logger().Error("unable to get new missions request", zap.Error(err))
+		p.Error = err.Error()
+		w.WriteHeader(http.StatusBadRequest)
+		contributors.WritePageTemplate(w, p)
 		return
 	}
+	filter.MaskEmails = maskEmails(req)
 
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.ListMissionRequests")
+	page, err := h.contributorService.ListMissionRequests(ctx, filter)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get missions requests", zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	h.logParametersErrors(page.Requests...)
+	p.Requests = page.Requests
+	p.NextCursor = page.NextCursor
+	contributors.WritePageTemplate(w, p)
+
+}
+
+// logParametersErrors counts and logs requests whose stored parameters could not be decoded,
+// every time they are read
+func (h *ContributorHandler) logParametersErrors(requests ...contributor.UserMissionRequest) {
 	for _, request := range requests {
-		for key, param := range request.MissionParameters {
-			if (!strings.Contains(param, "http")) {
-				request.MissionParameters[key] = fmt.Sprint("http://", param)
-			}
+		if request.ParametersError == "" {
+			continue
 		}
-	}
 
-	p := &contributors.NewRequestsListPage{
-		Requests: requests,
+		metrics.ParametersDecodeErrors.WithLabelValues("read").Inc()
+		h.app.Logger().Error("unable to decode mission parameters",
+			zap.Int64("requestID", request.ID), zap.String("error", request.ParametersError))
 	}
-	contributors.WritePageTemplate(w, p)
+}
 
+// maskEmails reports whether user e-mails should be hidden from the request reader:
+// anonymous readers and viewer admins see them masked
+func maskEmails(req *http.Request) bool {
+	admin, ok := adminauth.AdminFromContext(req.Context())
+	return !ok || !admin.Role.Allows(adminauth.RoleModerator)
 }
 
 func (h *ContributorHandler) SetUserRequestStatus(w http.ResponseWriter, req *http.Request) {
 
+	admin, ok := adminauth.AdminFromContext(req.Context())
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
 	if err := req.ParseForm(); err != nil {
 		w.Write([]byte("cannot parse form"))
 		return
@@ -97,11 +179,131 @@ func (h *ContributorHandler) SetUserRequestStatus(w http.ResponseWriter, req *ht
 	}
 	status := entities.UserMissionStatus(req.FormValue("status"))
 
-	err = h.contributorService.SetMissionRequestStatus(int64(id), status)
+	//approve and reject buttons share one form, so the reason is sent only with rejection
+	reason := ""
+	if status == contributor.StatusRejected {
+		reason = req.FormValue("reason")
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.SetMissionRequestStatus")
+	err = h.contributorService.SetMissionRequestStatus(ctx, int64(id), status, contributor.StatusChange{
+		ActorID: admin.ID,
+		Reason:  reason,
+		Comment: strings.TrimSpace(req.FormValue("comment")),
+	})
+	tracing.EndSpan(span, err)
 	if err != nil {
-		h.app.Logger().Error("unable to set mission request status", zap.Error(err))
+		var transitionErr *contributor.InvalidTransitionError
+		var unknownErr *contributor.UnknownStatusError
+		var reasonErr *contributor.UnknownRejectionReasonError
+		switch {
+		case err == contributor.ErrUserMissionNotFound:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case err == contributor.ErrStatusConflict, err == contributor.ErrPaidThroughPayouts, err == contributor.ErrWithdrawnByContributor, errors.As(err, &transitionErr):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.As(err, &unknownErr), errors.As(err, &reasonErr),
+			err == contributor.ErrRejectionReasonRequired, err == contributor.ErrRejectionReasonNotAllowed:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			h.app.Logger().Error("unable to set mission request status", zap.Int64("adminID", admin.ID), zap.Error(err))
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
 		return
 	}
 
 	http.Redirect(w, req, "/admin/NewUserMissionRequests", http.StatusFound)
 }
+
+// BulkSetUserRequestStatus changes status of requests passed as "ids" form values and renders per-id report
+func (h *ContributorHandler) BulkSetUserRequestStatus(w http.ResponseWriter, req *http.Request) {
+
+	admin, ok := adminauth.AdminFromContext(req.Context())
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, "cannot parse form", http.StatusBadRequest)
+		return
+	}
+
+	ids := make([]int64, 0, len(req.PostForm["ids"]))
+	for _, value := range req.PostForm["ids"] {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			http.Error(w, "wrong id format", http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		http.Error(w, "no requests selected", http.StatusBadRequest)
+		return
+	}
+
+	status := entities.UserMissionStatus(req.FormValue("status"))
+
+	//approve and reject buttons share one form, so the reason is sent only with rejection
+	reason := ""
+	if status == contributor.StatusRejected {
+		reason = req.FormValue("reason")
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.BulkSetMissionRequestStatus")
+	result, err := h.contributorService.BulkSetMissionRequestStatus(ctx, ids, status, contributor.StatusChange{
+		ActorID: admin.ID,
+		Reason:  reason,
+		Comment: strings.TrimSpace(req.FormValue("comment")),
+	})
+	tracing.EndSpan(span, err)
+	if err != nil {
+		var reasonErr *contributor.UnknownRejectionReasonError
+		var bulkErr *contributor.BulkValidationError
+		switch {
+		case errors.As(err, &reasonErr), errors.As(err, &bulkErr), err == contributor.ErrRejectionReasonRequired, err == contributor.ErrRejectionReasonNotAllowed,
+			err == contributor.ErrPaidThroughPayouts, err == contributor.ErrWithdrawnByContributor:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			h.app.Logger().Error("unable to bulk set mission request status", zap.Int64("adminID", admin.ID), zap.Error(err))
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	p := &contributors.BulkResultPage{
+		BasePage: contributors.BasePage{CSRFToken: h.csrfToken(req)},
+		Status:   string(status),
+		Result:   result,
+	}
+	if !result.Applied {
+		w.WriteHeader(http.StatusConflict)
+	}
+	contributors.WritePageTemplate(w, p)
+}
+
+func (h *ContributorHandler) RenderUserRequestStatusHistory(w http.ResponseWriter, req *http.Request) {
+
+	id, err := strconv.ParseInt(req.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "wrong id format", http.StatusBadRequest)
+		return
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.GetMissionRequestStatusHistory")
+	history, err := h.contributorService.GetMissionRequestStatusHistory(ctx, id)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get mission request status history", zap.Int64("id", id), zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	p := &contributors.StatusHistoryPage{
+		BasePage:  contributors.BasePage{CSRFToken: h.csrfToken(req)},
+		RequestID: id,
+		History:   history,
+	}
+	contributors.WritePageTemplate(w, p)
+}
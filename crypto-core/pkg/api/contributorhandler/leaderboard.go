@@ -0,0 +1,167 @@
+package contributorhandler
+
+import (
+	"net/http"
+
+	"github.com/bfg-dev/crypto-core/pkg/api"
+	"github.com/bfg-dev/crypto-core/pkg/bfgerrors"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributorauth"
+	"github.com/bfg-dev/crypto-core/pkg/services/leaderboard"
+	"github.com/bfg-dev/crypto-core/pkg/templates/contributors"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type optOutState struct {
+	OptedOut bool `json:"optedOut"`
+}
+
+// GetPublicLeaderboard ranks contributors who did not opt out, user identifiers are left out.
+// Parameters: period and limit.
+func (h *ContributorHandler) GetPublicLeaderboard(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+	board, resp, err := h.leaderboard(req, true)
+	if board == nil {
+		return resp, err
+	}
+	return api.SuccessResponse(board.Public()), nil
+}
+
+// GetLeaderboard ranks all contributors for admins. Parameters: period and limit.
+func (h *ContributorHandler) GetLeaderboard(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+	board, resp, err := h.leaderboard(req, false)
+	if board == nil {
+		return resp, err
+	}
+	return api.SuccessResponse(board), nil
+}
+
+// leaderboard returns the board or the response to send instead
+func (h *ContributorHandler) leaderboard(req *http.Request, public bool) (*leaderboard.Leaderboard, *api.Response, error) {
+
+	limit, err := parseIntParam(req.URL.Query(), "limit")
+	if err != nil {
+		return nil, api.ErrorResponse(err.Error()), nil
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "leaderboardService.Leaderboard")
+	board, err := h.leaderboardService.Leaderboard(ctx, req.URL.Query().Get("period"), public, int(limit))
+	tracing.EndSpan(span, err)
+
+	var limitErr *leaderboard.LimitError
+	if err == leaderboard.ErrUnknownPeriod || errors.As(err, &limitErr) {
+		return nil, api.ErrorResponse(err.Error()), nil
+	}
+	if err != nil {
+		h.app.Logger().Error("unable to get leaderboard", zap.Error(err))
+		return nil, nil, bfgerrors.NewApiIntErr(err, nil, "unable to get leaderboard", nil)
+	}
+
+	return board, nil, nil
+}
+
+// GetMissionStats reports requests by mission. Parameters: period.
+func (h *ContributorHandler) GetMissionStats(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	ctx, span := tracing.StartSpan(req.Context(), "leaderboardService.MissionReport")
+	report, err := h.leaderboardService.MissionReport(ctx, req.URL.Query().Get("period"))
+	tracing.EndSpan(span, err)
+	if err == leaderboard.ErrUnknownPeriod {
+		return api.ErrorResponse(err.Error()), nil
+	}
+	if err != nil {
+		h.app.Logger().Error("unable to get mission stats", zap.Error(err))
+		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get mission stats", nil)
+	}
+
+	return api.SuccessResponse(report), nil
+}
+
+func (h *ContributorHandler) RenderLeaderboard(w http.ResponseWriter, req *http.Request) {
+
+	ctx, span := tracing.StartSpan(req.Context(), "leaderboardService.Leaderboard")
+	board, err := h.leaderboardService.Leaderboard(ctx, req.URL.Query().Get("period"), false, leaderboard.MaxLimit)
+	tracing.EndSpan(span, err)
+	if err == leaderboard.ErrUnknownPeriod {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		h.app.Logger().Error("unable to get leaderboard", zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	contributors.WritePageTemplate(w, &contributors.LeaderboardPage{
+		Board:   board,
+		Periods: h.leaderboardService.Periods(),
+	})
+}
+
+func (h *ContributorHandler) RenderMissionStats(w http.ResponseWriter, req *http.Request) {
+
+	ctx, span := tracing.StartSpan(req.Context(), "leaderboardService.MissionReport")
+	report, err := h.leaderboardService.MissionReport(ctx, req.URL.Query().Get("period"))
+	tracing.EndSpan(span, err)
+	if err == leaderboard.ErrUnknownPeriod {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		h.app.Logger().Error("unable to get mission stats", zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	contributors.WritePageTemplate(w, &contributors.MissionStatsPage{
+		Report:  report,
+		Periods: h.leaderboardService.Periods(),
+	})
+}
+
+// GetLeaderboardOptOut tells whether the authenticated contributor is hidden from public leaderboard
+func (h *ContributorHandler) GetLeaderboardOptOut(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	userID, ok := contributorauth.UserIDFromContext(req.Context())
+	if !ok {
+		return nil, bfgerrors.NewApiIntErr(errors.New("contributor is not authenticated"), nil, "unable to get opt-out", nil)
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "leaderboardService.IsOptedOut")
+	optedOut, err := h.leaderboardService.IsOptedOut(ctx, userID)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get opt-out", zap.Int64("userID", userID), zap.Error(err))
+		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get opt-out", nil)
+	}
+
+	return api.SuccessResponse(optOutState{OptedOut: optedOut}), nil
+}
+
+// OptOutOfLeaderboard hides the authenticated contributor from public leaderboard
+func (h *ContributorHandler) OptOutOfLeaderboard(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+	return h.setLeaderboardOptOut(req, true)
+}
+
+// OptInToLeaderboard shows the authenticated contributor in public leaderboard again
+func (h *ContributorHandler) OptInToLeaderboard(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+	return h.setLeaderboardOptOut(req, false)
+}
+
+func (h *ContributorHandler) setLeaderboardOptOut(req *http.Request, optOut bool) (*api.Response, error) {
+
+	userID, ok := contributorauth.UserIDFromContext(req.Context())
+	if !ok {
+		return nil, bfgerrors.NewApiIntErr(errors.New("contributor is not authenticated"), nil, "unable to save opt-out", nil)
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "leaderboardService.SetOptOut")
+	err := h.leaderboardService.SetOptOut(ctx, userID, optOut)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to save opt-out", zap.Int64("userID", userID), zap.Error(err))
+		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to save opt-out", nil)
+	}
+
+	return api.SuccessResponse(optOutState{OptedOut: optOut}), nil
+}
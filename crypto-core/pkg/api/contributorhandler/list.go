@@ -0,0 +1,82 @@
+package contributorhandler
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/bfg-dev/crypto-core/pkg/helpers/timeformat"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/pkg/errors"
+)
+
+// anyStatus lists requests of every status, missing status parameter lists new requests only
+const anyStatus = "any"
+
+// parseListFilter reads mission requests list filter from query parameters:
+// status, missionId, userId, from and to (dates, both inclusive), sort, limit and cursor
+func parseListFilter(query url.Values) (contributor.ListFilter, error) {
+	filter := contributor.ListFilter{
+		Status: contributor.StatusNew,
+		Sort:   contributor.SortOrder(query.Get("sort")),
+	}
+
+	if status, ok := query["status"]; ok {
+		filter.Status = entities.UserMissionStatus(status[0])
+		if status[0] == anyStatus {
+			filter.Status = ""
+		}
+	}
+
+	var err error
+	if filter.MissionID, err = parseIntParam(query, "missionId"); err != nil {
+		return filter, err
+	}
+
+	if filter.UserID, err = parseIntParam(query, "userId"); err != nil {
+		return filter, err
+	}
+
+	limit, err := parseIntParam(query, "limit")
+	if err != nil {
+		return filter, err
+	}
+	filter.Limit = int(limit)
+
+	if value := query.Get("from"); value != "" {
+		if filter.CreatedFrom, err = time.Parse(timeformat.Date, value); err != nil {
+			return filter, errors.New("from should be a date in YYYY-MM-DD format")
+		}
+	}
+
+	if value := query.Get("to"); value != "" {
+		to, err := time.Parse(timeformat.Date, value)
+		if err != nil {
+			return filter, errors.New("to should be a date in YYYY-MM-DD format")
+		}
+		filter.CreatedTo = to.AddDate(0, 0, 1)
+	}
+
+	if value := query.Get("cursor"); value != "" {
+		if filter.After, err = contributor.ParseListCursor(value); err != nil {
+			return filter, err
+		}
+	}
+
+	return filter, filter.Normalize()
+}
+
+func parseIntParam(query url.Values, name string) (int64, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number <= 0 {
+		return 0, errors.Errorf("%s should be a positive number", name)
+	}
+
+	return number, nil
+}
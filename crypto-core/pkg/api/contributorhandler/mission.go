@@ -0,0 +1,332 @@
+package contributorhandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/api"
+	"github.com/bfg-dev/crypto-core/pkg/bfgerrors"
+	"github.com/bfg-dev/crypto-core/pkg/helpers/timeformat"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/bfg-dev/crypto-core/pkg/templates/contributors"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const missionsPath = "/admin/missions"
+
+// parameterOptional marks not required parameter in admin form
+const parameterOptional = "optional"
+
+// maxMissionBodyBytes limits json body of mission create and update requests
+const maxMissionBodyBytes = 64 << 10
+
+func (h *ContributorHandler) ListMissions(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.ListMissions")
+	missions, err := h.contributorService.ListMissions(ctx, req.URL.Query().Get("archived") == "true")
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get missions", zap.Error(err))
+		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get missions", nil)
+	}
+
+	return api.SuccessResponse(missions), nil
+}
+
+func (h *ContributorHandler) CreateMission(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	var in contributor.MissionInput
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxMissionBodyBytes)).Decode(&in); err != nil {
+		return api.ErrorResponse("wrong mission json"), nil
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.CreateMission")
+	mission, err := h.contributorService.CreateMission(ctx, in)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		return h.missionErrorResponse(err, "unable to create mission")
+	}
+
+	return api.SuccessResponse(mission), nil
+}
+
+func (h *ContributorHandler) UpdateMission(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+	if err != nil {
+		return api.ErrorResponse("wrong id format"), nil
+	}
+
+	var in contributor.MissionInput
+	if err = json.NewDecoder(http.MaxBytesReader(w, req.Body, maxMissionBodyBytes)).Decode(&in); err != nil {
+		return api.ErrorResponse("wrong mission json"), nil
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.UpdateMission")
+	mission, err := h.contributorService.UpdateMission(ctx, id, in)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		return h.missionErrorResponse(err, "unable to update mission")
+	}
+
+	return api.SuccessResponse(mission), nil
+}
+
+func (h *ContributorHandler) ArchiveMission(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+	if err != nil {
+		return api.ErrorResponse("wrong id format"), nil
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.ArchiveMission")
+	err = h.contributorService.ArchiveMission(ctx, id)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		return h.missionErrorResponse(err, "unable to archive mission")
+	}
+
+	return api.SuccessResponse(id), nil
+}
+
+func (h *ContributorHandler) missionErrorResponse(err error, msg string) (*api.Response, error) {
+	if isMissionClientError(err) {
+		return api.ErrorResponse(err.Error()), nil
+	}
+
+	h.app.Logger().Error(msg, zap.Error(err))
+	return nil, bfgerrors.NewApiIntErr(err, nil, msg, nil)
+}
+
+func isMissionClientError(err error) bool {
+	var validationErr *contributor.MissionValidationError
+	return errors.As(err, &validationErr) || err == contributor.ErrMissionNotFound || err == contributor.ErrMissionArchived
+}
+
+func (h *ContributorHandler) RenderMissions(w http.ResponseWriter, req *http.Request) {
+
+	includeArchived := req.URL.Query().Get("archived") == "true"
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.ListMissions")
+	missions, err := h.contributorService.ListMissions(ctx, includeArchived)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get missions", zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	p := &contributors.MissionsListPage{
+		BasePage:        contributors.BasePage{CSRFToken: h.csrfToken(req)},
+		Missions:        missions,
+		IncludeArchived: includeArchived,
+	}
+	contributors.WritePageTemplate(w, p)
+}
+
+// RenderMissionForm renders empty form at /admin/missions/new and filled one at /admin/missions/{id}/edit
+func (h *ContributorHandler) RenderMissionForm(w http.ResponseWriter, req *http.Request) {
+
+	p := &contributors.MissionFormPage{
+		BasePage: contributors.BasePage{CSRFToken: h.csrfToken(req)},
+	}
+
+	if value, ok := mux.Vars(req)["id"]; ok {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			http.Error(w, "wrong id format", http.StatusBadRequest)
+			return
+		}
+
+		ctx, span := tracing.StartSpan(req.Context(), "contributorService.GetMission")
+		mission, err := h.contributorService.GetMission(ctx, id)
+		tracing.EndSpan(span, err)
+		if err == contributor.ErrMissionNotFound {
+			http.NotFound(w, req)
+			return
+		}
+		if err != nil {
+			h.app.Logger().Error("unable to get mission", zap.Int64("id", id), zap.Error(err))
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		p.MissionID = id
+		p.Form = missionForm(mission)
+	}
+
+	contributors.WritePageTemplate(w, p)
+}
+
+// SaveMission creates mission posted to /admin/missions or updates one posted to /admin/missions/{id}
+func (h *ContributorHandler) SaveMission(w http.ResponseWriter, req *http.Request) {
+
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, "cannot parse form", http.StatusBadRequest)
+		return
+	}
+
+	p := &contributors.MissionFormPage{
+		BasePage: contributors.BasePage{CSRFToken: h.csrfToken(req)},
+		Form: contributors.MissionForm{
+			Title:           req.PostFormValue("title"),
+			Description:     req.PostFormValue("description"),
+			Reward:          req.PostFormValue("reward"),
+			ParameterSchema: req.PostFormValue("parameterSchema"),
+			ActiveFrom:      req.PostFormValue("activeFrom"),
+			ActiveTo:        req.PostFormValue("activeTo"),
+		},
+	}
+
+	var err error
+	if value, ok := mux.Vars(req)["id"]; ok {
+		if p.MissionID, err = strconv.ParseInt(value, 10, 64); err != nil {
+			http.Error(w, "wrong id format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	in, err := missionInput(p.Form)
+	if err == nil {
+		ctx, span := tracing.StartSpan(req.Context(), "contributorService.SaveMission")
+		if p.MissionID == 0 {
+			_, err = h.contributorService.CreateMission(ctx, in)
+		} else {
+			_, err = h.contributorService.UpdateMission(ctx, p.MissionID, in)
+		}
+		tracing.EndSpan(span, err)
+	}
+
+	if err != nil && !isMissionClientError(err) {
+		h.app.Logger().Error("unable to save mission", zap.Int64("id", p.MissionID), zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	if err != nil {
+		p.Error = err.Error()
+		w.WriteHeader(http.StatusBadRequest)
+		contributors.WritePageTemplate(w, p)
+		return
+	}
+
+	http.Redirect(w, req, missionsPath, http.StatusSeeOther)
+}
+
+func (h *ContributorHandler) ArchiveMissionForm(w http.ResponseWriter, req *http.Request) {
+
+	id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "wrong id format", http.StatusBadRequest)
+		return
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.ArchiveMission")
+	err = h.contributorService.ArchiveMission(ctx, id)
+	tracing.EndSpan(span, err)
+	if err == contributor.ErrMissionNotFound {
+		http.NotFound(w, req)
+		return
+	}
+	if err != nil {
+		if isMissionClientError(err) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		h.app.Logger().Error("unable to archive mission", zap.Int64("id", id), zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, req, missionsPath, http.StatusSeeOther)
+}
+
+func missionForm(mission *contributor.Mission) contributors.MissionForm {
+	form := contributors.MissionForm{
+		Title:           mission.Title,
+		Description:     mission.Description,
+		Reward:          mission.Reward.String(),
+		ParameterSchema: formatParameterSchema(mission.ParameterSchema),
+	}
+	if mission.ActiveFrom != nil {
+		form.ActiveFrom = mission.ActiveFrom.Format(timeformat.Date)
+	}
+	if mission.ActiveTo != nil {
+		form.ActiveTo = mission.ActiveTo.Format(timeformat.Date)
+	}
+	return form
+}
+
+// formatParameterSchema prints schema for admin form, one "name:type" line per parameter
+// with ":optional" suffix for not required ones
+func formatParameterSchema(schema contributor.ParameterSchema) string {
+	lines := make([]string, len(schema))
+	for i, spec := range schema {
+		lines[i] = spec.Name + ":" + string(spec.Type)
+		if !spec.Required {
+			lines[i] += ":" + parameterOptional
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// missionInput parses admin form, see formatParameterSchema for parameters format.
+// Like activeTo of the json api, activeTo date of the form is exclusive: mission is not active since that day
+func missionInput(form contributors.MissionForm) (contributor.MissionInput, error) {
+	in := contributor.MissionInput{
+		Title:           form.Title,
+		Description:     form.Description,
+		ParameterSchema: make(contributor.ParameterSchema, 0),
+	}
+
+	if value := strings.TrimSpace(form.Reward); value != "" {
+		reward, err := decimal.NewFromString(value)
+		if err != nil {
+			return in, &contributor.MissionValidationError{Field: "reward", Message: "should be a number"}
+		}
+		in.Reward = reward
+	}
+
+	for _, line := range strings.Split(form.ParameterSchema, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		parts := strings.Split(line, ":")
+		if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != parameterOptional) {
+			return in, &contributor.MissionValidationError{Field: "parameterSchema", Message: fmt.Sprintf("wrong line %q", line)}
+		}
+
+		in.ParameterSchema = append(in.ParameterSchema, contributor.ParameterSpec{
+			Name:     strings.TrimSpace(parts[0]),
+			Type:     contributor.ParameterType(strings.TrimSpace(parts[1])),
+			Required: len(parts) == 2,
+		})
+	}
+
+	if value := strings.TrimSpace(form.ActiveFrom); value != "" {
+		from, err := time.Parse(timeformat.Date, value)
+		if err != nil {
+			return in, &contributor.MissionValidationError{Field: "activeFrom", Message: "should be a date in YYYY-MM-DD format"}
+		}
+		in.ActiveFrom = &from
+	}
+
+	if value := strings.TrimSpace(form.ActiveTo); value != "" {
+		to, err := time.Parse(timeformat.Date, value)
+		if err != nil {
+			return in, &contributor.MissionValidationError{Field: "activeTo", Message: "should be a date in YYYY-MM-DD format"}
+		}
+		in.ActiveTo = &to
+	}
+
+	return in, nil
+}
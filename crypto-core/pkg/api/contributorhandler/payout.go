@@ -0,0 +1,197 @@
+package contributorhandler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bfg-dev/crypto-core/pkg/api"
+	"github.com/bfg-dev/crypto-core/pkg/bfgerrors"
+	"github.com/bfg-dev/crypto-core/pkg/services/adminauth"
+	"github.com/bfg-dev/crypto-core/pkg/services/payout"
+	"github.com/bfg-dev/crypto-core/pkg/templates/contributors"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const payoutsPath = "/admin/payouts"
+
+// GetPayouts lists payouts in "status" (pending by default), of "batchId" if it is set
+func (h *ContributorHandler) GetPayouts(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	status := payout.Status(req.URL.Query().Get("status"))
+	if status == "" {
+		status = payout.StatusPending
+	}
+
+	batchID, err := parseIntParam(req.URL.Query(), "batchId")
+	if err != nil {
+		return api.ErrorResponse(err.Error()), nil
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "payoutService.ListPayouts")
+	payouts, err := h.payoutService.ListPayouts(ctx, status, batchID, maskEmails(req))
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get payouts", zap.Error(err))
+		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get payouts", nil)
+	}
+
+	return api.SuccessResponse(payouts), nil
+}
+
+func (h *ContributorHandler) RenderPayouts(w http.ResponseWriter, req *http.Request) {
+
+	ctx, span := tracing.StartSpan(req.Context(), "payoutService.ListPayouts")
+	payouts, err := h.payoutService.ListPayouts(ctx, payout.StatusPending, 0, maskEmails(req))
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get payouts", zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	ctx, span = tracing.StartSpan(req.Context(), "payoutService.ListBatches")
+	batches, err := h.payoutService.ListBatches(ctx)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get payout batches", zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	p := &contributors.PayoutsPage{
+		BasePage: contributors.BasePage{CSRFToken: h.csrfToken(req)},
+		Pending:  payouts,
+		Batches:  batches,
+	}
+	contributors.WritePageTemplate(w, p)
+}
+
+// CreatePayoutBatch puts pending payouts passed as "ids" form values into a new batch
+func (h *ContributorHandler) CreatePayoutBatch(w http.ResponseWriter, req *http.Request) {
+
+	admin, ok := adminauth.AdminFromContext(req.Context())
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, "cannot parse form", http.StatusBadRequest)
+		return
+	}
+
+	ids := make([]int64, 0, len(req.PostForm["ids"]))
+	for _, value := range req.PostForm["ids"] {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			http.Error(w, "wrong id format", http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "payoutService.CreateBatch")
+	_, err := h.payoutService.CreateBatch(ctx, admin.ID, ids)
+	tracing.EndSpan(span, err)
+	if err == payout.ErrNothingToBatch {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	var sizeErr *payout.BatchSizeError
+	if errors.As(err, &sizeErr) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		h.app.Logger().Error("unable to create payout batch", zap.Int64("adminID", admin.ID), zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, req, payoutsPath, http.StatusSeeOther)
+}
+
+// ExportPayoutBatch writes batch payouts as csv, amounts are both in ATx units and in tokens
+func (h *ContributorHandler) ExportPayoutBatch(w http.ResponseWriter, req *http.Request) {
+
+	id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "wrong id format", http.StatusBadRequest)
+		return
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "payoutService.ExportBatch")
+	_, payouts, err := h.payoutService.ExportBatch(ctx, id)
+	tracing.EndSpan(span, err)
+	if err == payout.ErrBatchNotFound {
+		http.NotFound(w, req)
+		return
+	}
+	if err != nil {
+		h.app.Logger().Error("unable to export payout batch", zap.Int64("batchID", id), zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="payout-batch-%d.csv"`, id))
+
+	out := csv.NewWriter(w)
+	out.Write([]string{"payout_id", "user_mission_id", "user_id", "user_email", "mission", "amount_atx", "amount"})
+	for _, p := range payouts {
+		out.Write([]string{
+			strconv.FormatInt(p.ID, 10),
+			strconv.FormatInt(p.UserMissionID, 10),
+			strconv.FormatInt(p.UserID, 10),
+			p.UserEmail,
+			p.Mission,
+			p.Amount.String(),
+			p.Tokens().String(),
+		})
+	}
+	out.Flush()
+	if err = out.Error(); err != nil {
+		h.app.Logger().Error("unable to write payout batch csv", zap.Int64("batchID", id), zap.Error(err))
+	}
+}
+
+// MarkPayoutBatchPaid marks batch paid with payment "reference", its mission requests become paid
+func (h *ContributorHandler) MarkPayoutBatchPaid(w http.ResponseWriter, req *http.Request) {
+
+	admin, ok := adminauth.AdminFromContext(req.Context())
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "wrong id format", http.StatusBadRequest)
+		return
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "payoutService.MarkBatchPaid")
+	err = h.payoutService.MarkBatchPaid(ctx, id, admin.ID, req.FormValue("reference"))
+	tracing.EndSpan(span, err)
+	if err != nil {
+		switch err {
+		case payout.ErrBatchNotFound:
+			http.NotFound(w, req)
+		case payout.ErrBatchPaid:
+			http.Error(w, err.Error(), http.StatusConflict)
+		case payout.ErrEmptyReference:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			h.app.Logger().Error("unable to mark payout batch paid", zap.Int64("batchID", id), zap.Error(err))
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	http.Redirect(w, req, payoutsPath, http.StatusSeeOther)
+}
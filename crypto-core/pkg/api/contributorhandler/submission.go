@@ -0,0 +1,167 @@
+package contributorhandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/bfg-dev/crypto-core/pkg/api"
+	"github.com/bfg-dev/crypto-core/pkg/bfgerrors"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributorauth"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// maxSubmissionBodyBytes limits json body of mission submission
+const maxSubmissionBodyBytes = 16 << 10
+
+type submissionInput struct {
+	MissionID         int64             `json:"missionId"`
+	MissionParameters map[string]string `json:"missionParameters"`
+}
+
+// ListActiveMissions lists missions the authenticated contributor can submit
+func (h *ContributorHandler) ListActiveMissions(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.ListActiveMissions")
+	missions, err := h.contributorService.ListActiveMissions(ctx)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get active missions", zap.Error(err))
+		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get active missions", nil)
+	}
+
+	return api.SuccessResponse(missions), nil
+}
+
+func (h *ContributorHandler) SubmitMission(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	userID, ok := contributorauth.UserIDFromContext(req.Context())
+	if !ok {
+		return nil, bfgerrors.NewApiIntErr(errors.New("contributor is not authenticated"), nil, "unable to submit mission", nil)
+	}
+
+	var in submissionInput
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxSubmissionBodyBytes)).Decode(&in); err != nil {
+		return api.ErrorResponse("wrong submission json"), nil
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.Submit")
+	request, err := h.contributorService.Submit(ctx, userID, in.MissionID, in.MissionParameters)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		return h.submissionErrorResponse(err, userID, "unable to submit mission")
+	}
+
+	return api.SuccessResponse(request), nil
+}
+
+// ListSubmissions lists requests of the authenticated contributor, all statuses by default.
+// See parseListFilter for parameters, userId is ignored.
+func (h *ContributorHandler) ListSubmissions(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	userID, ok := contributorauth.UserIDFromContext(req.Context())
+	if !ok {
+		return nil, bfgerrors.NewApiIntErr(errors.New("contributor is not authenticated"), nil, "unable to get submissions", nil)
+	}
+
+	query := req.URL.Query()
+	if _, ok := query["status"]; !ok {
+		query.Set("status", anyStatus)
+	}
+	query.Del("userId")
+
+	filter, err := parseListFilter(query)
+	if err != nil {
+		return api.ErrorResponse(err.Error()), nil
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.ListSubmissions")
+	page, err := h.contributorService.ListSubmissions(ctx, userID, filter)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get submissions", zap.Int64("userID", userID), zap.Error(err))
+		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get submissions", nil)
+	}
+	h.logParametersErrors(page.Requests...)
+
+	return api.SuccessResponse(page), nil
+}
+
+func (h *ContributorHandler) GetSubmission(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	userID, ok := contributorauth.UserIDFromContext(req.Context())
+	if !ok {
+		return nil, bfgerrors.NewApiIntErr(errors.New("contributor is not authenticated"), nil, "unable to get submission", nil)
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+	if err != nil {
+		return api.ErrorResponse("wrong id format"), nil
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.GetSubmission")
+	request, err := h.contributorService.GetSubmission(ctx, userID, id)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		return h.submissionErrorResponse(err, userID, "unable to get submission")
+	}
+	h.logParametersErrors(*request)
+
+	return api.SuccessResponse(request), nil
+}
+
+func (h *ContributorHandler) WithdrawSubmission(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+
+	userID, ok := contributorauth.UserIDFromContext(req.Context())
+	if !ok {
+		return nil, bfgerrors.NewApiIntErr(errors.New("contributor is not authenticated"), nil, "unable to withdraw submission", nil)
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+	if err != nil {
+		return api.ErrorResponse("wrong id format"), nil
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), "contributorService.WithdrawSubmission")
+	err = h.contributorService.WithdrawSubmission(ctx, userID, id)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		return h.submissionErrorResponse(err, userID, "unable to withdraw submission")
+	}
+
+	return api.SuccessResponse(id), nil
+}
+
+func (h *ContributorHandler) submissionErrorResponse(err error, userID int64, msg string) (*api.Response, error) {
+	var paramsErr *contributor.ParametersValidationError
+	if errors.As(err, &paramsErr) {
+		return api.ErrorResponse(paramsErr.Errors), nil
+	}
+
+	if isSubmissionClientError(err) {
+		return api.ErrorResponse(err.Error()), nil
+	}
+
+	h.app.Logger().Error(msg, zap.Int64("userID", userID), zap.Error(err))
+	return nil, bfgerrors.NewApiIntErr(err, nil, msg, nil)
+}
+
+func isSubmissionClientError(err error) bool {
+	var limitErr *contributor.RateLimitError
+	var transitionErr *contributor.InvalidTransitionError
+	switch {
+	case errors.As(err, &limitErr), errors.As(err, &transitionErr):
+		return true
+	}
+
+	switch err {
+	case contributor.ErrMissionNotFound, contributor.ErrMissionNotActive, contributor.ErrDuplicateSubmission,
+		contributor.ErrUserMissionNotFound, contributor.ErrStatusConflict:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package dsindexeshandler
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	summaryCacheVersionV1 = "1.0"
+	summaryCacheVersionV2 = "1.1"
+)
+
+func summaryCacheKey(version string, assetID int64) string {
+	return fmt.Sprintf("tokens.summary:%s:%d", version, assetID)
+}
+
+// cachedSummary returns summary of the asset from cache.
+// On cache miss the summary is computed and cached, cache failures are logged and do not fail the request
+func (h *DSIndexesHandler) cachedSummary(version string, assetID int64, compute func() (map[string]decimal.Decimal, error)) (map[string]decimal.Decimal, error) {
+	if data, ok := h.cachedSummaryGet(version, assetID); ok {
+		return data, nil
+	}
+
+	data, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	h.cachedSummarySet(version, assetID, data)
+
+	return data, nil
+}
+
+// cachedSummaryGet returns cached summary of the asset, second result is false on cache miss or failure
+func (h *DSIndexesHandler) cachedSummaryGet(version string, assetID int64) (map[string]decimal.Decimal, bool) {
+	key := summaryCacheKey(version, assetID)
+
+	cached, ok, err := h.summaryCache.Get(key)
+	if err != nil {
+		h.app.Logger().Error("unable to get summary from cache", zap.String("key", key), zap.Error(err))
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	data := make(map[string]decimal.Decimal)
+	if err = json.Unmarshal(cached, &data); err != nil {
+		h.app.Logger().Error("unable to decode cached summary", zap.String("key", key), zap.Error(err))
+		return nil, false
+	}
+
+	return data, true
+}
+
+func (h *DSIndexesHandler) cachedSummarySet(version string, assetID int64, data map[string]decimal.Decimal) {
+	key := summaryCacheKey(version, assetID)
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		h.app.Logger().Error("unable to encode summary for cache", zap.String("key", key), zap.Error(err))
+		return
+	}
+
+	if err = h.summaryCache.Set(key, encoded, h.summaryCacheTTL); err != nil {
+		h.app.Logger().Error("unable to put summary to cache", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// setCacheHeaders lets CDNs cache summary responses for the same time as the handler does.
+// It returns true when the client already has the same data by If-None-Match, the handler
+// answers errNotModified then
+func (h *DSIndexesHandler) setCacheHeaders(w http.ResponseWriter, req *http.Request, data interface{}) bool {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		h.app.Logger().Error("unable to encode response for etag", zap.Error(err))
+		return false
+	}
+
+	sum := sha1.Sum(encoded)
+	etag := fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:]))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.summaryCacheTTL.Seconds())))
+
+	return etagMatches(req.Header.Get("If-None-Match"), etag)
+}
+
+// etagMatches checks etag against If-None-Match list, weak comparison is used as RFC 7232 requires for it
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
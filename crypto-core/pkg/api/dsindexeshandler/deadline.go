@@ -0,0 +1,47 @@
+package dsindexeshandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bfg-dev/crypto-core/pkg/api"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// summaryConcurrency limits summaries of one request computed at the same time
+const summaryConcurrency = 4
+
+// waitLookups returns result of g, or error of ctx when it is done first. Lookups not started yet
+// see the done context and are skipped. Services are not context aware, so lookups already running
+// cannot be stopped: they are left to finish in background and their results are dropped.
+// A timed out request leaks up to summaryConcurrency summaries with all their lookups in flight,
+// each holding its db connection until the query returns
+func waitLookups(ctx context.Context, g *errgroup.Group) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- g.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// summaryError answers 504 when summary deadline is exceeded. Canceled request means
+// the client is gone, it is not an error of the service
+func (h *DSIndexesHandler) summaryError(err error, req *http.Request) (*api.Response, error) {
+	switch errors.Cause(err) {
+	case context.DeadlineExceeded:
+		h.app.Logger().Error("summary request timed out", zap.Stringer("url", req.URL), zap.Duration("timeout", h.summaryTimeout))
+		return nil, &statusError{status: http.StatusGatewayTimeout, message: "summary request timed out"}
+	case context.Canceled:
+		h.app.Logger().Info("summary request canceled by client", zap.Stringer("url", req.URL))
+		return api.ErrorResponse("summary request canceled"), nil
+	}
+	return nil, err
+}
@@ -1,8 +1,11 @@
 package dsindexeshandler
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/bfg-dev/crypto-core/pkg/api"
 	"github.com/bfg-dev/crypto-core/pkg/api/params"
@@ -12,13 +15,16 @@ import (
 	"github.com/bfg-dev/crypto-core/pkg/services"
 	"github.com/bfg-dev/crypto-core/pkg/services/asset"
 	"github.com/bfg-dev/crypto-core/pkg/services/blockchain"
+	"github.com/bfg-dev/crypto-core/pkg/services/cache"
 	"github.com/bfg-dev/crypto-core/pkg/services/cryptofund"
 	"github.com/bfg-dev/crypto-core/pkg/services/tokenemission"
 	"github.com/bfg-dev/crypto-core/pkg/services/tokenredemption"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
 	"github.com/bfg-dev/crypto-core/pkg/types"
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 type DSIndexesHandler struct {
@@ -28,6 +34,9 @@ type DSIndexesHandler struct {
 	tokenRedemptionService tokenredemption.Service
 	buybackService         blockchain.BuybackService
 	cryptofundService      cryptofund.Service
+	summaryTimeout         time.Duration
+	summaryCache           cache.Cache
+	summaryCacheTTL        time.Duration
 }
 
 func New(
@@ -37,6 +46,9 @@ func New(
 	tokenredemptionsrv tokenredemption.Service,
 	buybackService blockchain.BuybackService,
 	cryptofundsrv cryptofund.Service,
+	summaryTimeout time.Duration,
+	summaryCache cache.Cache,
+	summaryCacheTTL time.Duration,
 ) (*DSIndexesHandler, error) {
 
 	if application == nil {
@@ -63,6 +75,18 @@ func New(
 		return nil, errors.New("DSIndexesHandler.New, cryptofundsrv must be not empty")
 	}
 
+	if summaryTimeout <= 0 {
+		return nil, errors.New("DSIndexesHandler.New, summaryTimeout must be positive")
+	}
+
+	if summaryCache == nil {
+		return nil, errors.New("DSIndexesHandler.New, summaryCache must be not empty")
+	}
+
+	if summaryCacheTTL <= 0 {
+		return nil, errors.New("DSIndexesHandler.New, summaryCacheTTL must be positive")
+	}
+
 	return &DSIndexesHandler{
 		app:                    application,
 		assetService:           assetsrv,
@@ -70,6 +94,9 @@ func New(
 		tokenRedemptionService: tokenredemptionsrv,
 		buybackService:         buybackService,
 		cryptofundService:      cryptofundsrv,
+		summaryTimeout:         summaryTimeout,
+		summaryCache:           summaryCache,
+		summaryCacheTTL:        summaryCacheTTL,
 	}, nil
 }
 
@@ -81,115 +108,247 @@ func (h *DSIndexesHandler) GetSummary(w http.ResponseWriter, req *http.Request)
 
 	assetSymbol := strings.ToLower(methodParams.AssetSymbol[3:])
 
+	_, span := tracing.StartSpan(req.Context(), "assetService.GetAssetBySymbol")
 	asset, err := h.assetService.GetAssetBySymbol(assetSymbol)
+	tracing.EndSpan(span, err)
 	if err != nil || asset == nil {
 		h.app.Logger().Error("unable to get asset by symbol", zap.String("assetSymbol", assetSymbol), zap.Error(err))
 		return api.ErrorResponse("unable to get asset by symbol"), nil
 	}
 
-	// get ledger by asset
-	ledgerIDs, err := h.tokenEmissionService.GetLedgerIDs([]int64{asset.ID})
+	data, err := h.cachedSummary(summaryCacheVersionV1, asset.ID, func() (map[string]decimal.Decimal, error) {
+		return h.getSummaryData(req.Context(), asset.ID, methodParams, req)
+	})
 	if err != nil {
-		h.app.Logger().Error("unable to get ledger ids", zap.Int64("asset.ID", asset.ID), zap.Error(err))
-		return nil, bfgerrors.NewApiIntErr(err, methodParams, "unable to get ledger ids", nil)
+		return nil, err
 	}
 
-	issuedTokenCount, err := h.tokenEmissionService.GetIssuedTokenCount(ledgerIDs)
-	if err != nil {
-		h.app.Logger().Error("unable to get issuedTokenCount", zap.Int64s("ledgerIDs", ledgerIDs), zap.Error(err))
-		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get issuedTokenCount", types.MapI{"url": req.URL})
+	if h.setCacheHeaders(w, req, data) {
+		return nil, errNotModified
 	}
 
-	tobeIssuedTokenCount, err := h.tokenEmissionService.GetNotIssuedTokenCount(ledgerIDs)
-	if err != nil {
-		h.app.Logger().Error("unable to get GetNotIssuedTokenCount", zap.Int64s("ledgerIDs", ledgerIDs), zap.Error(err))
-		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get GetNotIssuedTokenCount", types.MapI{"url": req.URL})
+	return api.SuccessResponse(data), nil
+}
+
+func (h *DSIndexesHandler) GetSummaryV2(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+	methodParams := dsindexes.NewSummaryParams(*req.URL)
+	if validationErrors := params.MustValidateParams(&methodParams); validationErrors != nil {
+		return api.ErrorResponse(validationErrors), nil
 	}
 
-	burnedBuyback, err := h.buybackService.GetBuybackBurnedAmount(asset.ID)
-	if err != nil {
-		h.app.Logger().Error("GetBuybackBurnedAmount error", zap.Int64("assetID", asset.ID), zap.Error(err))
-		return nil, bfgerrors.NewApiIntErr(err, nil, "GetBuybackBurnedAmount error", nil)
+	assetSymbol := strings.ToLower(methodParams.AssetSymbol[3:])
+
+	_, span := tracing.StartSpan(req.Context(), "assetService.GetAssetBySymbol")
+	a, err := h.assetService.GetAssetBySymbol(assetSymbol)
+	tracing.EndSpan(span, err)
+	if err != nil || a == nil {
+		h.app.Logger().Error("unable to get asset by symbol", zap.String("assetSymbol", assetSymbol), zap.Error(err))
+		return api.ErrorResponse("unable to get asset by symbol"), nil
 	}
 
-	// burned redemption
-	redemptionBooks, err := h.tokenRedemptionService.GetActiveBooks([]int64{asset.ID})
+	ctx, cancel := context.WithTimeout(req.Context(), h.summaryTimeout)
+	defer cancel()
+
+	summaries, err := h.getSummariesV2Data(ctx, map[string]int64{methodParams.AssetSymbol: a.ID}, methodParams, req)
 	if err != nil {
-		h.app.Logger().Error("Cannot get active burningman books by asset id", zap.Int64("asset.ID", asset.ID), zap.Error(err))
-		return nil, bfgerrors.NewApiIntErr(err, nil, "Cannot get active burningman books by asset id", nil)
+		return h.summaryError(err, req)
 	}
 
-	redemptionBookIDs := make([]int64, len(redemptionBooks))
-	for i, item := range redemptionBooks {
-		redemptionBookIDs[i] = item.ID
+	data := summaries[methodParams.AssetSymbol]
+	if h.setCacheHeaders(w, req, data) {
+		return nil, errNotModified
 	}
 
-	burnedRedemption, err := h.tokenRedemptionService.GetRedemptionBurnedTotalAmount(redemptionBookIDs)
-	if err != nil {
-		h.app.Logger().Error("Cannot get GetRedemptionBurnedTotalAmount", zap.Int64s("redemptionBookIDs", redemptionBookIDs), zap.Error(err))
-		return nil, bfgerrors.NewApiIntErr(err, nil, "Cannot get GetRedemptionBurnedTotalAmount", nil)
+	return api.SuccessResponse(data), nil
+}
+
+// GetSummaries returns summaries in the format of GetSummaryV2 for the list of asset symbols
+// passed comma separated in "symbols" query parameter. Summaries are keyed by the normalized
+// symbol, see dsindexes.NormalizeSymbol
+func (h *DSIndexesHandler) GetSummaries(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
+	methodParams := dsindexes.NewSummariesParams(*req.URL)
+
+	if len(methodParams.Symbols) == 0 {
+		return api.ErrorResponse("symbols must be not empty"), nil
 	}
 
-	tobeBurnedTokenCount, err := h.tokenRedemptionService.GetRedemptionTobeBurnedTotalAmount(redemptionBookIDs)
+	if len(methodParams.Symbols) > dsindexes.MaxSummariesSymbols {
+		return api.ErrorResponse(fmt.Sprintf("symbols count must be not greater than %d", dsindexes.MaxSummariesSymbols)), nil
+	}
+
+	assets := make(map[string]int64)
+	for _, symbolParams := range methodParams.SummaryParams() {
+		symbolParams := symbolParams
+		if validationErrors := params.MustValidateParams(&symbolParams); validationErrors != nil {
+			return api.ErrorResponse(validationErrors), nil
+		}
+
+		assetSymbol := strings.ToLower(symbolParams.AssetSymbol[3:])
+
+		_, span := tracing.StartSpan(req.Context(), "assetService.GetAssetBySymbol")
+		a, err := h.assetService.GetAssetBySymbol(assetSymbol)
+		tracing.EndSpan(span, err)
+		if err != nil || a == nil {
+			h.app.Logger().Error("unable to get asset by symbol", zap.String("assetSymbol", assetSymbol), zap.Error(err))
+			return api.ErrorResponse(fmt.Sprintf("unable to get asset by symbol %s", symbolParams.AssetSymbol)), nil
+		}
+
+		assets[symbolParams.AssetSymbol] = a.ID
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), h.summaryTimeout)
+	defer cancel()
+
+	summaries, err := h.getSummariesV2Data(ctx, assets, methodParams, req)
 	if err != nil {
-		h.app.Logger().Error("unable to get GetRedemptionTobeBurnedTotalAmount", zap.Int64s("redemptionBookIDs", redemptionBookIDs), zap.Error(err))
-		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get GetRedemptionTobeBurnedTotalAmount", types.MapI{"url": req.URL})
+		return h.summaryError(err, req)
 	}
 
-	data := map[string]decimal.Decimal{
-		"issued":            currency.DenormalizeATx(*issuedTokenCount),
-		"to_be_issued":      currency.DenormalizeATx(*tobeIssuedTokenCount),
-		"to_be_burned":      currency.DenormalizeATx(*tobeBurnedTokenCount),
-		"burned_buyback":    currency.DenormalizeATx(*burnedBuyback),
-		"burned_redemption": currency.DenormalizeATx((*burnedRedemption).Add(*burnedBuyback)),
+	if h.setCacheHeaders(w, req, summaries) {
+		return nil, errNotModified
 	}
 
-	return api.SuccessResponse(data), nil
+	return api.SuccessResponse(summaries), nil
 }
 
-func (h *DSIndexesHandler) GetSummaryV2(w http.ResponseWriter, req *http.Request) (*api.Response, error) {
-	methodParams := dsindexes.NewSummaryParams(*req.URL)
-	if validationErrors := params.MustValidateParams(&methodParams); validationErrors != nil {
-		return api.ErrorResponse(validationErrors), nil
+// getSummariesV2Data returns summaries of assets keyed by symbol. Cached summaries are reused,
+// the rest are computed concurrently, at most summaryConcurrency at a time
+func (h *DSIndexesHandler) getSummariesV2Data(ctx context.Context, assets map[string]int64, methodParams interface{}, req *http.Request) (map[string]map[string]decimal.Decimal, error) {
+	summaries := make(map[string]map[string]decimal.Decimal, len(assets))
+
+	missing := make(map[int64][]string)
+	missingIDs := make([]int64, 0)
+	for symbol, assetID := range assets {
+		if data, ok := h.cachedSummaryGet(summaryCacheVersionV2, assetID); ok {
+			summaries[symbol] = data
+			continue
+		}
+
+		if _, ok := missing[assetID]; !ok {
+			missingIDs = append(missingIDs, assetID)
+		}
+		missing[assetID] = append(missing[assetID], symbol)
 	}
 
-	assetSymbol := strings.ToLower(methodParams.AssetSymbol[3:])
+	if len(missingIDs) == 0 {
+		return summaries, nil
+	}
 
-	a, err := h.assetService.GetAssetBySymbol(assetSymbol)
-	if err != nil || a == nil {
-		h.app.Logger().Error("unable to get asset by symbol", zap.String("assetSymbol", assetSymbol), zap.Error(err))
-		return api.ErrorResponse("unable to get asset by symbol"), nil
+	computed := make([]map[string]decimal.Decimal, len(missingIDs))
+
+	g, gctx := errgroup.WithContext(ctx)
+	slots := make(chan struct{}, summaryConcurrency)
+	for i, assetID := range missingIDs {
+		i, assetID := i, assetID
+		g.Go(func() error {
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			if gctx.Err() != nil {
+				return gctx.Err()
+			}
+
+			sources, err := h.getSummarySources(gctx, assetID, methodParams)
+			if err != nil {
+				return err
+			}
+
+			computed[i], err = h.getSummaryV2Data(gctx, assetID, sources, methodParams, req)
+			return err
+		})
+	}
+
+	if err := waitLookups(ctx, g); err != nil {
+		return nil, err
+	}
+
+	for i, assetID := range missingIDs {
+		h.cachedSummarySet(summaryCacheVersionV2, assetID, computed[i])
+		for _, symbol := range missing[assetID] {
+			summaries[symbol] = computed[i]
+		}
+	}
+
+	return summaries, nil
+}
+
+// summarySources are ledgers and active redemption books summary of an asset is computed from
+type summarySources struct {
+	ledgerIDs         []int64
+	redemptionBookIDs []int64
+}
+
+// getSummarySources resolves ledgers and active redemption books of the asset. The list lookups
+// return ids of all passed assets together, so they are called per asset to keep the sums apart
+func (h *DSIndexesHandler) getSummarySources(ctx context.Context, assetID int64, methodParams interface{}) (summarySources, error) {
+	_, span := tracing.StartSpan(ctx, "tokenEmissionService.GetLedgerIDs")
+	ledgerIDs, err := h.tokenEmissionService.GetLedgerIDs([]int64{assetID})
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("unable to get ledger ids", zap.Int64("assetID", assetID), zap.Error(err))
+		return summarySources{}, bfgerrors.NewApiIntErr(err, methodParams, "unable to get ledger ids", nil)
+	}
+
+	_, span = tracing.StartSpan(ctx, "tokenRedemptionService.GetActiveBooks")
+	redemptionBooks, err := h.tokenRedemptionService.GetActiveBooks([]int64{assetID})
+	tracing.EndSpan(span, err)
+	if err != nil {
+		h.app.Logger().Error("Cannot get active burningman books by asset id", zap.Int64("assetID", assetID), zap.Error(err))
+		return summarySources{}, bfgerrors.NewApiIntErr(err, methodParams, "Cannot get active burningman books by asset id", nil)
 	}
 
-	ledgerIDs, err := h.tokenEmissionService.GetLedgerIDs([]int64{a.ID})
+	redemptionBookIDs := make([]int64, len(redemptionBooks))
+	for i, item := range redemptionBooks {
+		redemptionBookIDs[i] = item.ID
+	}
+
+	return summarySources{
+		ledgerIDs:         ledgerIDs,
+		redemptionBookIDs: redemptionBookIDs,
+	}, nil
+}
+
+// getSummaryData collects supply figures of one asset in the format of GetSummary
+func (h *DSIndexesHandler) getSummaryData(ctx context.Context, assetID int64, methodParams interface{}, req *http.Request) (map[string]decimal.Decimal, error) {
+	// get ledger by asset
+	_, span := tracing.StartSpan(ctx, "tokenEmissionService.GetLedgerIDs")
+	ledgerIDs, err := h.tokenEmissionService.GetLedgerIDs([]int64{assetID})
+	tracing.EndSpan(span, err)
 	if err != nil {
-		h.app.Logger().Error("unable to get ledger ids", zap.Int64("asset.ID", a.ID), zap.Error(err))
+		h.app.Logger().Error("unable to get ledger ids", zap.Int64("assetID", assetID), zap.Error(err))
 		return nil, bfgerrors.NewApiIntErr(err, methodParams, "unable to get ledger ids", nil)
 	}
 
+	_, span = tracing.StartSpan(ctx, "tokenEmissionService.GetIssuedTokenCount")
 	issuedTokenCount, err := h.tokenEmissionService.GetIssuedTokenCount(ledgerIDs)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		h.app.Logger().Error("unable to get issuedTokenCount", zap.Int64s("ledgerIDs", ledgerIDs), zap.Error(err))
 		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get issuedTokenCount", types.MapI{"url": req.URL})
 	}
 
+	_, span = tracing.StartSpan(ctx, "tokenEmissionService.GetNotIssuedTokenCount")
 	tobeIssuedTokenCount, err := h.tokenEmissionService.GetNotIssuedTokenCount(ledgerIDs)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		h.app.Logger().Error("unable to get GetNotIssuedTokenCount", zap.Int64s("ledgerIDs", ledgerIDs), zap.Error(err))
 		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get GetNotIssuedTokenCount", types.MapI{"url": req.URL})
 	}
 
-	// burned on buybacks
-	burnedBuyback, err := h.buybackService.GetBuybackBurnedAmount(a.ID)
+	_, span = tracing.StartSpan(ctx, "buybackService.GetBuybackBurnedAmount")
+	burnedBuyback, err := h.buybackService.GetBuybackBurnedAmount(assetID)
+	tracing.EndSpan(span, err)
 	if err != nil {
-		h.app.Logger().Error("GetBuybackBurnedAmount error", zap.Int64("assetID", a.ID), zap.Error(err))
+		h.app.Logger().Error("GetBuybackBurnedAmount error", zap.Int64("assetID", assetID), zap.Error(err))
 		return nil, bfgerrors.NewApiIntErr(err, nil, "GetBuybackBurnedAmount error", nil)
 	}
 
-	// burned on burningman
-	redemptionBooks, err := h.tokenRedemptionService.GetActiveBooks([]int64{a.ID})
+	// burned redemption
+	_, span = tracing.StartSpan(ctx, "tokenRedemptionService.GetActiveBooks")
+	redemptionBooks, err := h.tokenRedemptionService.GetActiveBooks([]int64{assetID})
+	tracing.EndSpan(span, err)
 	if err != nil {
-		h.app.Logger().Error("Cannot get active burningman books by asset id", zap.Int64("asset.ID", a.ID), zap.Error(err))
+		h.app.Logger().Error("Cannot get active burningman books by asset id", zap.Int64("assetID", assetID), zap.Error(err))
 		return nil, bfgerrors.NewApiIntErr(err, nil, "Cannot get active burningman books by asset id", nil)
 	}
 
@@ -198,18 +357,139 @@ func (h *DSIndexesHandler) GetSummaryV2(w http.ResponseWriter, req *http.Request
 		redemptionBookIDs[i] = item.ID
 	}
 
-	burnedBurningman, err := h.tokenRedemptionService.GetRedemptionBurnedTotalAmount(redemptionBookIDs)
+	_, span = tracing.StartSpan(ctx, "tokenRedemptionService.GetRedemptionBurnedTotalAmount")
+	burnedRedemption, err := h.tokenRedemptionService.GetRedemptionBurnedTotalAmount(redemptionBookIDs)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		h.app.Logger().Error("Cannot get GetRedemptionBurnedTotalAmount", zap.Int64s("redemptionBookIDs", redemptionBookIDs), zap.Error(err))
 		return nil, bfgerrors.NewApiIntErr(err, nil, "Cannot get GetRedemptionBurnedTotalAmount", nil)
 	}
 
+	_, span = tracing.StartSpan(ctx, "tokenRedemptionService.GetRedemptionTobeBurnedTotalAmount")
 	tobeBurnedTokenCount, err := h.tokenRedemptionService.GetRedemptionTobeBurnedTotalAmount(redemptionBookIDs)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		h.app.Logger().Error("unable to get GetRedemptionTobeBurnedTotalAmount", zap.Int64s("redemptionBookIDs", redemptionBookIDs), zap.Error(err))
 		return nil, bfgerrors.NewApiIntErr(err, nil, "unable to get GetRedemptionTobeBurnedTotalAmount", types.MapI{"url": req.URL})
 	}
 
+	data := map[string]decimal.Decimal{
+		"issued":            currency.DenormalizeATx(*issuedTokenCount),
+		"to_be_issued":      currency.DenormalizeATx(*tobeIssuedTokenCount),
+		"to_be_burned":      currency.DenormalizeATx(*tobeBurnedTokenCount),
+		"burned_buyback":    currency.DenormalizeATx(*burnedBuyback),
+		"burned_redemption": currency.DenormalizeATx((*burnedRedemption).Add(*burnedBuyback)),
+	}
+
+	return data, nil
+}
+
+// getSummaryV2Data collects supply figures of one asset in the format of GetSummaryV2.
+// Emission, buyback and redemption lookups do not depend on each other and run concurrently,
+// the first failed lookup cancels the ones not started yet. Error of ctx is returned as is
+// when it is done first, see summaryError
+func (h *DSIndexesHandler) getSummaryV2Data(ctx context.Context, assetID int64, sources summarySources, methodParams interface{}, req *http.Request) (map[string]decimal.Decimal, error) {
+	var (
+		issuedTokenCount     *decimal.Decimal
+		tobeIssuedTokenCount *decimal.Decimal
+		burnedBuyback        *decimal.Decimal
+		burnedBurningman     *decimal.Decimal
+		tobeBurnedTokenCount *decimal.Decimal
+	)
+
+	ledgerIDs, redemptionBookIDs := sources.ledgerIDs, sources.redemptionBookIDs
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	// issued
+	g.Go(func() error {
+		if gctx.Err() != nil {
+			return gctx.Err()
+		}
+
+		var err error
+		_, span := tracing.StartSpan(gctx, "tokenEmissionService.GetIssuedTokenCount")
+		issuedTokenCount, err = h.tokenEmissionService.GetIssuedTokenCount(ledgerIDs)
+		tracing.EndSpan(span, err)
+		if err != nil {
+			h.app.Logger().Error("unable to get issuedTokenCount", zap.Int64s("ledgerIDs", ledgerIDs), zap.Error(err))
+			return bfgerrors.NewApiIntErr(err, nil, "unable to get issuedTokenCount", types.MapI{"url": req.URL})
+		}
+		return nil
+	})
+
+	// to be issued
+	g.Go(func() error {
+		if gctx.Err() != nil {
+			return gctx.Err()
+		}
+
+		var err error
+		_, span := tracing.StartSpan(gctx, "tokenEmissionService.GetNotIssuedTokenCount")
+		tobeIssuedTokenCount, err = h.tokenEmissionService.GetNotIssuedTokenCount(ledgerIDs)
+		tracing.EndSpan(span, err)
+		if err != nil {
+			h.app.Logger().Error("unable to get GetNotIssuedTokenCount", zap.Int64s("ledgerIDs", ledgerIDs), zap.Error(err))
+			return bfgerrors.NewApiIntErr(err, nil, "unable to get GetNotIssuedTokenCount", types.MapI{"url": req.URL})
+		}
+		return nil
+	})
+
+	// burned on buybacks
+	g.Go(func() error {
+		if gctx.Err() != nil {
+			return gctx.Err()
+		}
+
+		var err error
+		_, span := tracing.StartSpan(gctx, "buybackService.GetBuybackBurnedAmount")
+		burnedBuyback, err = h.buybackService.GetBuybackBurnedAmount(assetID)
+		tracing.EndSpan(span, err)
+		if err != nil {
+			h.app.Logger().Error("GetBuybackBurnedAmount error", zap.Int64("assetID", assetID), zap.Error(err))
+			return bfgerrors.NewApiIntErr(err, nil, "GetBuybackBurnedAmount error", nil)
+		}
+		return nil
+	})
+
+	// burned on burningman
+	g.Go(func() error {
+		if gctx.Err() != nil {
+			return gctx.Err()
+		}
+
+		var err error
+		_, span := tracing.StartSpan(gctx, "tokenRedemptionService.GetRedemptionBurnedTotalAmount")
+		burnedBurningman, err = h.tokenRedemptionService.GetRedemptionBurnedTotalAmount(redemptionBookIDs)
+		tracing.EndSpan(span, err)
+		if err != nil {
+			h.app.Logger().Error("Cannot get GetRedemptionBurnedTotalAmount", zap.Int64s("redemptionBookIDs", redemptionBookIDs), zap.Error(err))
+			return bfgerrors.NewApiIntErr(err, nil, "Cannot get GetRedemptionBurnedTotalAmount", nil)
+		}
+		return nil
+	})
+
+	// to be burned on burningman
+	g.Go(func() error {
+		if gctx.Err() != nil {
+			return gctx.Err()
+		}
+
+		var err error
+		_, span := tracing.StartSpan(gctx, "tokenRedemptionService.GetRedemptionTobeBurnedTotalAmount")
+		tobeBurnedTokenCount, err = h.tokenRedemptionService.GetRedemptionTobeBurnedTotalAmount(redemptionBookIDs)
+		tracing.EndSpan(span, err)
+		if err != nil {
+			h.app.Logger().Error("unable to get GetRedemptionTobeBurnedTotalAmount", zap.Int64s("redemptionBookIDs", redemptionBookIDs), zap.Error(err))
+			return bfgerrors.NewApiIntErr(err, nil, "unable to get GetRedemptionTobeBurnedTotalAmount", types.MapI{"url": req.URL})
+		}
+		return nil
+	})
+
+	if err := waitLookups(ctx, g); err != nil {
+		return nil, err
+	}
+
 	burnedTotal := burnedBurningman.Add(*burnedBuyback)
 
 	data := map[string]decimal.Decimal{
@@ -222,5 +502,5 @@ func (h *DSIndexesHandler) GetSummaryV2(w http.ResponseWriter, req *http.Request
 		"burned_total":      currency.DenormalizeATx(burnedTotal),
 	}
 
-	return api.SuccessResponse(data), nil
+	return data, nil
 }
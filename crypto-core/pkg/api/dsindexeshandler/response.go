@@ -0,0 +1,51 @@
+package dsindexeshandler
+
+import (
+	"net/http"
+
+	"github.com/bfg-dev/crypto-core/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// statusError is returned by summary handlers to answer with a bare status code and an optional
+// plain text message, nothing else is written for it
+type statusError struct {
+	status  int
+	message string
+}
+
+// errNotModified answers 304 to conditional requests for data the client already has
+var errNotModified = &statusError{status: http.StatusNotModified}
+
+func (e *statusError) Error() string {
+	if e.message == "" {
+		return http.StatusText(e.status)
+	}
+	return e.message
+}
+
+func (e *statusError) write(w http.ResponseWriter) {
+	if e.message == "" {
+		w.WriteHeader(e.status)
+		return
+	}
+	http.Error(w, e.message, e.status)
+}
+
+// ResponseHandler works as api.ResponseHandler, except that statusError returned by f
+// is written by itself and api.ResponseHandler is not called for the request
+func ResponseHandler(f func(w http.ResponseWriter, req *http.Request) (*api.Response, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		response, err := f(w, req)
+
+		var status *statusError
+		if errors.As(err, &status) {
+			status.write(w)
+			return
+		}
+
+		api.ResponseHandler(func(http.ResponseWriter, *http.Request) (*api.Response, error) {
+			return response, err
+		})(w, req)
+	}
+}
@@ -0,0 +1,152 @@
+package healthhandler
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/services"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Checker reports an error when a dependency of the service is not usable
+type Checker func(ctx context.Context) error
+
+type HealthHandler struct {
+	app          services.App
+	checks       map[string]Checker
+	checkTimeout time.Duration
+	shuttingDown int32
+}
+
+func New(
+	application services.App,
+	checks map[string]Checker,
+	checkTimeout time.Duration,
+) (*HealthHandler, error) {
+
+	if application == nil {
+		return nil, errors.New("HealthHandler.New, application cannot be empty")
+	}
+
+	if checkTimeout <= 0 {
+		return nil, errors.New("HealthHandler.New, checkTimeout must be positive")
+	}
+
+	return &HealthHandler{
+		app:          application,
+		checks:       checks,
+		checkTimeout: checkTimeout,
+	}, nil
+}
+
+// Shutdown makes readiness fail, so balancers stop routing requests while the server drains
+func (h *HealthHandler) Shutdown() {
+	atomic.StoreInt32(&h.shuttingDown, 1)
+}
+
+// Healthz is a liveness probe, it only shows the process is able to serve requests
+func (h *HealthHandler) Healthz(w http.ResponseWriter, req *http.Request) {
+	h.writeStatus(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readyz is a readiness probe, it runs all checks concurrently and fails if any of them fails
+func (h *HealthHandler) Readyz(w http.ResponseWriter, req *http.Request) {
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		h.writeStatus(w, http.StatusServiceUnavailable, map[string]string{"status": "shutting down"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), h.checkTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	failed := make(map[string]string)
+
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func(name string, check Checker) {
+			defer wg.Done()
+
+			if err := check(ctx); err != nil {
+				h.app.Logger().Warn("readiness check failed", zap.String("check", name), zap.Error(err))
+				mu.Lock()
+				failed[name] = err.Error()
+				mu.Unlock()
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if len(failed) > 0 {
+		names := make([]string, 0, len(failed))
+		for name := range failed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		h.writeStatus(w, http.StatusServiceUnavailable, map[string]interface{}{"status": "not ready", "failed": names, "errors": failed})
+		return
+	}
+
+	h.writeStatus(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func (h *HealthHandler) writeStatus(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.app.Logger().Error("unable to write health status", zap.Error(err))
+	}
+}
+
+// DBChecker pings the database connection pool
+func DBChecker(db *sqlx.DB) Checker {
+	return func(ctx context.Context) error {
+		return errors.Wrap(db.PingContext(ctx), "db ping failed")
+	}
+}
+
+// TCPChecker checks that a connection to addr can be established, e.g. to the amqp broker
+// whose connection provider does not report its state
+func TCPChecker(addr string) Checker {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return errors.Wrapf(err, "unable to connect to %s", addr)
+		}
+		return conn.Close()
+	}
+}
+
+// HTTPChecker checks that upstream at url responds without server error
+func HTTPChecker(url string) Checker {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return errors.Wrapf(err, "unable to build request to %s", url)
+		}
+
+		resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+		if err != nil {
+			return errors.Wrapf(err, "unable to request %s", url)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return errors.Errorf("%s responded with status %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
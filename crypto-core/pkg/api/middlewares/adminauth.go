@@ -0,0 +1,88 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/bfg-dev/crypto-core/pkg/services/adminauth"
+	"github.com/codegangsta/negroni"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// AdminSessionCookie holds token of the admin panel session
+const AdminSessionCookie = "cc_admin_session"
+
+// adminAuthMiddleware puts admin of the session into request context,
+// requests without active session are redirected to the login page
+type adminAuthMiddleware struct {
+	authService adminauth.Service
+	logger      *zap.Logger
+	loginPath   string
+}
+
+func (m *adminAuthMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	token := ""
+	if cookie, err := r.Cookie(AdminSessionCookie); err == nil {
+		token = cookie.Value
+	}
+
+	admin, err := m.authService.Authenticate(r.Context(), token)
+	if err != nil {
+		if errors.Cause(err) != adminauth.ErrSessionNotFound {
+			m.logger.Error("unable to authenticate admin", zap.Error(err))
+		}
+		http.Redirect(rw, r, m.loginPath, http.StatusSeeOther)
+		return
+	}
+
+	next(rw, r.WithContext(adminauth.WithAdmin(r.Context(), admin)))
+}
+
+func NewAdminAuth(authService adminauth.Service, logger *zap.Logger, loginPath string) (negroni.Handler, error) {
+	if authService == nil {
+		return nil, errors.New("NewAdminAuth, authService cannot be empty")
+	}
+
+	if logger == nil {
+		return nil, errors.New("NewAdminAuth, logger cannot be empty")
+	}
+
+	if loginPath == "" {
+		return nil, errors.New("NewAdminAuth, loginPath cannot be empty")
+	}
+
+	return &adminAuthMiddleware{
+		authService: authService,
+		logger:      logger,
+		loginPath:   loginPath,
+	}, nil
+}
+
+// adminRoleMiddleware rejects admins whose role does not grant the required one.
+// It must be placed after adminAuthMiddleware
+type adminRoleMiddleware struct {
+	role      adminauth.Role
+	forbidden http.HandlerFunc
+}
+
+func (m *adminRoleMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	admin, ok := adminauth.AdminFromContext(r.Context())
+	if !ok || !admin.Role.Allows(m.role) {
+		m.forbidden(rw, r)
+		return
+	}
+
+	next(rw, r)
+}
+
+func NewAdminRole(role adminauth.Role, forbidden http.HandlerFunc) (negroni.Handler, error) {
+	if !role.Allows(role) {
+		return nil, errors.Errorf("NewAdminRole, unknown role %q", role)
+	}
+
+	if forbidden == nil {
+		return nil, errors.New("NewAdminRole, forbidden handler cannot be empty")
+	}
+
+	return &adminRoleMiddleware{role, forbidden}, nil
+}
@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/bfg-dev/crypto-core/pkg/services/contributorauth"
+	"github.com/codegangsta/negroni"
+	"github.com/pkg/errors"
+)
+
+const bearerPrefix = "Bearer "
+
+// contributorAuthMiddleware puts contributor of the bearer token into request context,
+// requests without valid token get 401
+type contributorAuthMiddleware struct {
+	authService contributorauth.Service
+}
+
+func (m *contributorAuthMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		rw.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	userID, err := m.authService.Authenticate(strings.TrimPrefix(header, bearerPrefix))
+	if err != nil {
+		rw.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	next(rw, r.WithContext(contributorauth.WithUserID(r.Context(), userID)))
+}
+
+func NewContributorAuth(authService contributorauth.Service) (negroni.Handler, error) {
+	if authService == nil {
+		return nil, errors.New("NewContributorAuth, authService cannot be empty")
+	}
+
+	return &contributorAuthMiddleware{authService}, nil
+}
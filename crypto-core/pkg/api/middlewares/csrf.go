@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/bfg-dev/crypto-core/pkg/services/adminauth"
+	"github.com/codegangsta/negroni"
+	"github.com/pkg/errors"
+)
+
+// csrfMiddleware rejects state changing requests without csrf token of the admin session
+type csrfMiddleware struct {
+	forbidden http.HandlerFunc
+}
+
+func (m *csrfMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		next(rw, r)
+		return
+	}
+
+	sessionToken := ""
+	if cookie, err := r.Cookie(AdminSessionCookie); err == nil {
+		sessionToken = cookie.Value
+	}
+
+	csrfToken := r.Header.Get("X-CSRF-Token")
+	if csrfToken == "" {
+		csrfToken = r.PostFormValue(adminauth.CSRFFormField)
+	}
+
+	if !adminauth.ValidCSRFToken(sessionToken, csrfToken) {
+		m.forbidden(rw, r)
+		return
+	}
+
+	next(rw, r)
+}
+
+func NewCSRF(forbidden http.HandlerFunc) (negroni.Handler, error) {
+	if forbidden == nil {
+		return nil, errors.New("NewCSRF, forbidden handler cannot be empty")
+	}
+
+	return &csrfMiddleware{forbidden}, nil
+}
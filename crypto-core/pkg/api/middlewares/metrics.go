@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
+	"github.com/codegangsta/negroni"
+	"github.com/gorilla/mux"
+)
+
+// metricsMiddleware observes duration and status of requests per route
+type metricsMiddleware struct{}
+
+// ServeHTTP is placed inside error interceptor, so a panic is observed as 500 and passed on to it
+func (m *metricsMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	start := time.Now()
+
+	defer func() {
+		status := http.StatusOK
+		if res, ok := rw.(negroni.ResponseWriter); ok && res.Status() != 0 {
+			status = res.Status()
+		}
+
+		p := recover()
+		if p != nil {
+			status = http.StatusInternalServerError
+		}
+
+		// route template keeps label cardinality bounded
+		route := "unknown"
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		metrics.HTTPRequestDuration.
+			WithLabelValues(route, r.Method, strconv.Itoa(status)).
+			Observe(time.Since(start).Seconds())
+
+		if p != nil {
+			panic(p)
+		}
+	}()
+
+	next(rw, r)
+}
+
+func NewMetrics() (negroni.Handler, error) {
+	return &metricsMiddleware{}, nil
+}
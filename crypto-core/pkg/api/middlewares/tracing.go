@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/codegangsta/negroni"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// tracingMiddleware starts a span per request continuing trace passed in request headers
+type tracingMiddleware struct{}
+
+func (m *tracingMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	route := r.URL.Path
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			route = template
+		}
+	}
+
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	ctx, span := tracing.StartSpan(ctx, fmt.Sprintf("%s %s", r.Method, route),
+		attribute.String("http.method", r.Method),
+		attribute.String("http.route", route),
+	)
+	defer span.End()
+
+	next(rw, r.WithContext(ctx))
+
+	if res, ok := rw.(negroni.ResponseWriter); ok && res.Status() != 0 {
+		span.SetAttributes(attribute.Int("http.status_code", res.Status()))
+		if res.Status() >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(res.Status()))
+		}
+	}
+}
+
+func NewTracing() (negroni.Handler, error) {
+	return &tracingMiddleware{}, nil
+}
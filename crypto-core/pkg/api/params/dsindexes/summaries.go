@@ -0,0 +1,50 @@
+package dsindexes
+
+import (
+	"net/url"
+	"strings"
+)
+
+// MaxSummariesSymbols limits the number of assets requested in one summaries request
+const MaxSummariesSymbols = 100
+
+// SummariesParams are params of the multi-asset summaries request.
+// Symbols are taken from comma separated "symbols" values and normalized by NormalizeSymbol
+type SummariesParams struct {
+	Symbols []string
+}
+
+func NewSummariesParams(u url.URL) SummariesParams {
+	query := u.Query()
+
+	p := SummariesParams{}
+
+	seen := make(map[string]bool)
+	for _, value := range query["symbols"] {
+		for _, symbol := range strings.Split(value, ",") {
+			symbol = NormalizeSymbol(symbol)
+			if symbol == "" || seen[symbol] {
+				continue
+			}
+			seen[symbol] = true
+			p.Symbols = append(p.Symbols, symbol)
+		}
+	}
+
+	return p
+}
+
+// SummaryParams returns params of every requested symbol, so each of them is validated
+// the same way as the single asset summary request
+func (p SummariesParams) SummaryParams() []SummaryParams {
+	list := make([]SummaryParams, len(p.Symbols))
+	for i, symbol := range p.Symbols {
+		list[i] = SummaryParams{AssetSymbol: symbol}
+	}
+	return list
+}
+
+// NormalizeSymbol makes symbols differing only in case or surrounding spaces equal
+func NormalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Config keys, each of them can be overridden by env variable with the same name
+const (
+	KeyAddr           = "CRYPTO_HTTP_ADDR"
+	KeyReadTimeout    = "CRYPTO_HTTP_READ_TIMEOUT_SEC"
+	KeyWriteTimeout   = "CRYPTO_HTTP_WRITE_TIMEOUT_SEC"
+	KeyIdleTimeout    = "CRYPTO_HTTP_IDLE_TIMEOUT_SEC"
+	KeyDrainTimeout   = "CRYPTO_HTTP_DRAIN_TIMEOUT_SEC"
+	KeyShutdownDelay  = "CRYPTO_HTTP_SHUTDOWN_DELAY_SEC"
+	KeyMaxHeaderBytes = "CRYPTO_HTTP_MAX_HEADER_BYTES"
+	KeyTLSCertFile    = "CRYPTO_HTTP_TLS_CERT"
+	KeyTLSKeyFile     = "CRYPTO_HTTP_TLS_KEY"
+	KeyMetricsAddr    = "CRYPTO_METRICS_ADDR"
+)
+
+// ConfigSource is the part of application config used to read server settings,
+// env overrides are applied by the source
+type ConfigSource interface {
+	IsSet(key string) bool
+	GetString(key string) string
+	GetInt(key string) int
+}
+
+// Config describes http server settings
+type Config struct {
+	Addr           string
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+	IdleTimeout    time.Duration
+	DrainTimeout   time.Duration
+	MaxHeaderBytes int
+	TLSCertFile    string
+	TLSKeyFile     string
+	// ShutdownDelay is kept between failing readiness and closing listeners,
+	// so balancers notice the service is not ready before it stops accepting connections
+	ShutdownDelay time.Duration
+	// MetricsAddr is served by a separate listener, so metrics are not exposed with the api
+	MetricsAddr string
+}
+
+// TLSEnabled reports whether server should serve https
+func (c Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" || c.TLSKeyFile != ""
+}
+
+func (c Config) Validate() error {
+	if c.Addr == "" {
+		return errors.Errorf("server.Config, %s cannot be empty", KeyAddr)
+	}
+
+	if _, _, err := net.SplitHostPort(c.Addr); err != nil {
+		return errors.Wrapf(err, "server.Config, wrong %s %q", KeyAddr, c.Addr)
+	}
+
+	if _, _, err := net.SplitHostPort(c.MetricsAddr); err != nil {
+		return errors.Wrapf(err, "server.Config, wrong %s %q", KeyMetricsAddr, c.MetricsAddr)
+	}
+
+	if c.MetricsAddr == c.Addr {
+		return errors.Errorf("server.Config, %s must differ from %s", KeyMetricsAddr, KeyAddr)
+	}
+
+	if c.ReadTimeout < 0 || c.WriteTimeout < 0 || c.IdleTimeout < 0 || c.ShutdownDelay < 0 {
+		return errors.New("server.Config, timeouts cannot be negative")
+	}
+
+	if c.DrainTimeout <= 0 {
+		return errors.Errorf("server.Config, %s must be positive", KeyDrainTimeout)
+	}
+
+	if c.MaxHeaderBytes < 0 {
+		return errors.Errorf("server.Config, %s cannot be negative", KeyMaxHeaderBytes)
+	}
+
+	if c.TLSEnabled() {
+		if c.TLSCertFile == "" || c.TLSKeyFile == "" {
+			return errors.Errorf("server.Config, both %s and %s must be set to enable tls", KeyTLSCertFile, KeyTLSKeyFile)
+		}
+
+		for _, file := range []string{c.TLSCertFile, c.TLSKeyFile} {
+			if _, err := os.Stat(file); err != nil {
+				return errors.Wrapf(err, "server.Config, unable to access tls file %s", file)
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewServer creates http server with the configured address and limits
+func (c Config) NewServer(handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:           c.Addr,
+		Handler:        handler,
+		ReadTimeout:    c.ReadTimeout,
+		WriteTimeout:   c.WriteTimeout,
+		IdleTimeout:    c.IdleTimeout,
+		MaxHeaderBytes: c.MaxHeaderBytes,
+	}
+}
+
+// ReadConfig reads server settings from source, keys that are not set are taken from defaults.
+// Explicit zero values are kept, e.g. zero timeout disables it. The result is validated
+func ReadConfig(source ConfigSource, defaults Config) (Config, error) {
+	if source == nil {
+		return Config{}, errors.New("server.ReadConfig, source cannot be empty")
+	}
+
+	c := defaults
+
+	for key, value := range map[string]*string{
+		KeyAddr:        &c.Addr,
+		KeyTLSCertFile: &c.TLSCertFile,
+		KeyTLSKeyFile:  &c.TLSKeyFile,
+		KeyMetricsAddr: &c.MetricsAddr,
+	} {
+		if source.IsSet(key) {
+			*value = source.GetString(key)
+		}
+	}
+
+	if source.IsSet(KeyMaxHeaderBytes) {
+		c.MaxHeaderBytes = source.GetInt(KeyMaxHeaderBytes)
+	}
+
+	for key, value := range map[string]*time.Duration{
+		KeyReadTimeout:   &c.ReadTimeout,
+		KeyWriteTimeout:  &c.WriteTimeout,
+		KeyIdleTimeout:   &c.IdleTimeout,
+		KeyDrainTimeout:  &c.DrainTimeout,
+		KeyShutdownDelay: &c.ShutdownDelay,
+	} {
+		if source.IsSet(key) {
+			*value = time.Duration(source.GetInt(key)) * time.Second
+		}
+	}
+
+	if err := c.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return c, nil
+}
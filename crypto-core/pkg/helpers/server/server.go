@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Run serves srv until SIGINT or SIGTERM is received, then stops accepting new connections
+// and waits up to cfg.DrainTimeout for in-flight requests. onShutdown is called right after the signal,
+// e.g. to mark the service as not ready, and listeners are kept open for cfg.ShutdownDelay after it
+func Run(srv *http.Server, cfg Config, logger *zap.Logger, onShutdown func()) error {
+	if srv == nil {
+		return errors.New("server.Run, srv cannot be empty")
+	}
+
+	if logger == nil {
+		return errors.New("server.Run, logger cannot be empty")
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("http server started", zap.String("addr", srv.Addr), zap.Bool("tls", cfg.TLSEnabled()))
+		if cfg.TLSEnabled() {
+			serveErr <- srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+			return
+		}
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case err := <-serveErr:
+		return errors.Wrap(err, "server.Run, http server stopped")
+	case sig := <-signals:
+		logger.Info("shutdown signal received", zap.String("signal", sig.String()))
+	}
+
+	if onShutdown != nil {
+		onShutdown()
+	}
+
+	if cfg.ShutdownDelay > 0 {
+		logger.Info("waiting for balancers to notice shutdown", zap.Duration("delay", cfg.ShutdownDelay))
+		time.Sleep(cfg.ShutdownDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DrainTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "server.Run, unable to drain in-flight requests")
+	}
+
+	logger.Info("http server stopped")
+	return nil
+}
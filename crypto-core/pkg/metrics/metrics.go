@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "crypto"
+
+var (
+	// HTTPRequestDuration is observed by metrics middleware for every handled request
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of http requests by route, method and response status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	// DBQueryDuration is observed by postgres repositories, method is "repository.Method"
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Duration of database queries by repository method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	// ExternalCallDuration is observed by decorators of clients of other services, result is "ok" or "error"
+	ExternalCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "external",
+		Name:      "call_duration_seconds",
+		Help:      "Duration of calls to other services by service, method and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "result"})
+
+	// ParametersDecodeErrors counts decodes of stored mission parameters which failed: every read
+	// of a broken request ("read") and every duplicate check of submissions over it
+	ParametersDecodeErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "contributor",
+		Name:      "parameters_decode_errors_total",
+		Help:      "Failed decodes of stored mission request parameters, counted on every read or duplicate check.",
+	}, []string{"method"})
+)
+
+// ObserveDBQuery records duration of repository method started at start, intended to be deferred
+func ObserveDBQuery(method string, start time.Time) {
+	DBQueryDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// ObserveExternalCall records duration and result of service method started at start
+func ObserveExternalCall(service string, method string, start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	ExternalCallDuration.WithLabelValues(service, method, result).Observe(time.Since(start).Seconds())
+}
+
+// NewServer creates http server exposing registered metrics on /metrics
+func NewServer(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+}
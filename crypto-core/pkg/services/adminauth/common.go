@@ -0,0 +1,86 @@
+package adminauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type Role string
+
+const (
+	// RoleViewer can only browse the admin panel
+	RoleViewer Role = "viewer"
+	// RoleModerator can also change status of mission requests
+	RoleModerator Role = "moderator"
+	// RoleAdmin can do everything
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer:    1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+// Allows reports whether role grants permissions of required role
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrSessionNotFound    = errors.New("session not found or expired")
+)
+
+type Admin struct {
+	ID           int64     `db:"id" json:"id"`
+	UserID       int64     `db:"userId" json:"userId"`
+	Email        string    `db:"email" json:"email"`
+	Role         Role      `db:"role" json:"role"`
+	PasswordHash string    `db:"passwordHash" json:"-"`
+	CreatedAt    time.Time `db:"createdAt" json:"createdAt"`
+}
+
+type Session struct {
+	ID        string    `db:"id"`
+	AdminID   int64     `db:"adminId"`
+	CreatedAt time.Time `db:"createdAt"`
+	ExpiresAt time.Time `db:"expiresAt"`
+}
+
+type AdminRepository interface {
+	GetByID(ctx context.Context, id int64) (*Admin, error)
+	GetByEmail(ctx context.Context, email string) (*Admin, error)
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	GetActive(ctx context.Context, id string, now time.Time) (*Session, error)
+	Delete(ctx context.Context, id string) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type Service interface {
+	// Login checks credentials and returns token of the new session.
+	// Failed logins are limited per e-mail and per client address, *TooManyAttemptsError is returned over the limit
+	Login(ctx context.Context, email string, password string, clientIP string) (string, *Admin, error)
+	Logout(ctx context.Context, token string) error
+	// Authenticate returns admin of the active session
+	Authenticate(ctx context.Context, token string) (*Admin, error)
+}
+
+type contextKey struct{}
+
+// WithAdmin returns ctx carrying the acting admin
+func WithAdmin(ctx context.Context, admin *Admin) context.Context {
+	return context.WithValue(ctx, contextKey{}, admin)
+}
+
+// AdminFromContext returns the acting admin put into ctx by the auth middleware
+func AdminFromContext(ctx context.Context) (*Admin, bool) {
+	admin, ok := ctx.Value(contextKey{}).(*Admin)
+	return admin, ok && admin != nil
+}
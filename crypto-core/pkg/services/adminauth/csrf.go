@@ -0,0 +1,32 @@
+package adminauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// CSRFFormField is the name of form field carrying csrf token
+const CSRFFormField = "csrf_token"
+
+// CSRFToken derives csrf token of the session from its token, so it changes with every login
+// and does not need to be stored
+func CSRFToken(sessionToken string) string {
+	mac := hmac.New(sha256.New, []byte(sessionToken))
+	mac.Write([]byte("csrf"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidCSRFToken reports whether csrfToken belongs to the session
+func ValidCSRFToken(sessionToken string, csrfToken string) bool {
+	if sessionToken == "" || csrfToken == "" {
+		return false
+	}
+	return hmac.Equal([]byte(CSRFToken(sessionToken)), []byte(csrfToken))
+}
+
+// NewLoginCSRFSecret returns random secret of the login form. There is no session before login,
+// so the secret is kept in a cookie and the form carries CSRFToken of it
+func NewLoginCSRFSecret() (string, error) {
+	return newToken()
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/helpers/db"
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
+	"github.com/bfg-dev/crypto-core/pkg/services/adminauth"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const adminSelect = `
+	SELECT
+		a.id, a."userId", u.email, a.role, a."passwordHash", a."createdAt"
+	FROM
+		"ccAdmins" a
+	JOIN
+		"users" u ON a."userId" = u.id`
+
+type adminRepository struct {
+	db sqlx.ExtContext
+}
+
+func (repo *adminRepository) GetByID(ctx context.Context, id int64) (_ *adminauth.Admin, err error) {
+	defer metrics.ObserveDBQuery("adminRepository.GetByID", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "adminRepository.GetByID", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	rs := adminauth.Admin{}
+	row := repo.db.QueryRowxContext(ctx, adminSelect+` WHERE a.id = $1`, id)
+
+	err = row.StructScan(&rs)
+	if err != nil {
+		return nil, db.EmptyOrError(err, "adminRepository.GetByID, unable to get admin by id")
+	}
+
+	return &rs, nil
+}
+
+func (repo *adminRepository) GetByEmail(ctx context.Context, email string) (_ *adminauth.Admin, err error) {
+	defer metrics.ObserveDBQuery("adminRepository.GetByEmail", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "adminRepository.GetByEmail", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	rs := adminauth.Admin{}
+	row := repo.db.QueryRowxContext(ctx, adminSelect+` WHERE lower(u.email) = lower($1)`, email)
+
+	err = row.StructScan(&rs)
+	if err != nil {
+		return nil, db.EmptyOrError(err, "adminRepository.GetByEmail, unable to get admin by email")
+	}
+
+	return &rs, nil
+}
+
+func NewAdminRepository(db *sqlx.DB) (adminauth.AdminRepository, error) {
+	if db == nil {
+		return nil, errors.New("NewAdminRepository: db connection is empty")
+	}
+
+	return &adminRepository{db}, nil
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/helpers/db"
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
+	"github.com/bfg-dev/crypto-core/pkg/services/adminauth"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type sessionRepository struct {
+	db sqlx.ExtContext
+}
+
+func (repo *sessionRepository) Create(ctx context.Context, session *adminauth.Session) (err error) {
+	defer metrics.ObserveDBQuery("sessionRepository.Create", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "sessionRepository.Create", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	_, err = repo.db.ExecContext(ctx, `
+		INSERT INTO
+			"ccAdminSessions" (id, "adminId", "createdAt", "expiresAt")
+		VALUES
+			($1, $2, $3, $4)
+	`, session.ID, session.AdminID, session.CreatedAt, session.ExpiresAt)
+
+	return err
+}
+
+func (repo *sessionRepository) GetActive(ctx context.Context, id string, now time.Time) (_ *adminauth.Session, err error) {
+	defer metrics.ObserveDBQuery("sessionRepository.GetActive", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "sessionRepository.GetActive", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	rs := adminauth.Session{}
+	row := repo.db.QueryRowxContext(ctx, `SELECT * FROM "ccAdminSessions" WHERE "id" = $1 AND "expiresAt" > $2`, id, now)
+
+	err = row.StructScan(&rs)
+	if err != nil {
+		return nil, db.EmptyOrError(err, "sessionRepository.GetActive, unable to get session")
+	}
+
+	return &rs, nil
+}
+
+func (repo *sessionRepository) Delete(ctx context.Context, id string) (err error) {
+	defer metrics.ObserveDBQuery("sessionRepository.Delete", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "sessionRepository.Delete", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	_, err = repo.db.ExecContext(ctx, `DELETE FROM "ccAdminSessions" WHERE "id" = $1`, id)
+
+	return err
+}
+
+func (repo *sessionRepository) DeleteExpired(ctx context.Context, now time.Time) (err error) {
+	defer metrics.ObserveDBQuery("sessionRepository.DeleteExpired", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "sessionRepository.DeleteExpired", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	_, err = repo.db.ExecContext(ctx, `DELETE FROM "ccAdminSessions" WHERE "expiresAt" <= $1`, now)
+
+	return err
+}
+
+func NewSessionRepository(db *sqlx.DB) (adminauth.SessionRepository, error) {
+	if db == nil {
+		return nil, errors.New("NewSessionRepository: db connection is empty")
+	}
+
+	return &sessionRepository{db}, nil
+}
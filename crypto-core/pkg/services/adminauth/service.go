@@ -0,0 +1,149 @@
+package adminauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const tokenBytes = 32
+
+type service struct {
+	adminRepo   AdminRepository
+	sessionRepo SessionRepository
+	sessionTTL  time.Duration
+	throttle    *loginThrottle
+}
+
+func (s *service) Login(ctx context.Context, email string, password string, clientIP string) (string, *Admin, error) {
+	now := time.Now()
+	keys := loginKeys(email, clientIP)
+	if s.throttle.blocked(now, keys...) {
+		return "", nil, &TooManyAttemptsError{Limit: s.throttle.limit}
+	}
+
+	admin, err := s.adminRepo.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return "", nil, errors.Wrap(err, "adminauth.Login, unable to get admin")
+	}
+
+	if admin == nil {
+		s.throttle.fail(now, keys...)
+		return "", nil, ErrInvalidCredentials
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(password)); err != nil {
+		s.throttle.fail(now, keys...)
+		return "", nil, ErrInvalidCredentials
+	}
+
+	//failures of the client address are kept, they may belong to other accounts
+	s.throttle.reset(keys[0])
+
+	token, err := newToken()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "adminauth.Login, unable to generate session token")
+	}
+
+	now = now.UTC()
+	if err = s.sessionRepo.DeleteExpired(ctx, now); err != nil {
+		return "", nil, errors.Wrap(err, "adminauth.Login, unable to delete expired sessions")
+	}
+
+	err = s.sessionRepo.Create(ctx, &Session{
+		ID:        sessionID(token),
+		AdminID:   admin.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.sessionTTL),
+	})
+	if err != nil {
+		return "", nil, errors.Wrap(err, "adminauth.Login, unable to create session")
+	}
+
+	return token, admin, nil
+}
+
+func (s *service) Logout(ctx context.Context, token string) error {
+	err := s.sessionRepo.Delete(ctx, sessionID(token))
+	if err != nil {
+		return errors.Wrap(err, "adminauth.Logout, unable to delete session")
+	}
+	return nil
+}
+
+func (s *service) Authenticate(ctx context.Context, token string) (*Admin, error) {
+	if token == "" {
+		return nil, ErrSessionNotFound
+	}
+
+	session, err := s.sessionRepo.GetActive(ctx, sessionID(token), time.Now().UTC())
+	if err != nil {
+		return nil, errors.Wrap(err, "adminauth.Authenticate, unable to get session")
+	}
+
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	admin, err := s.adminRepo.GetByID(ctx, session.AdminID)
+	if err != nil {
+		return nil, errors.Wrap(err, "adminauth.Authenticate, unable to get admin")
+	}
+
+	if admin == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	return admin, nil
+}
+
+func newToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// sessionID is stored instead of the token, so leaked sessions table does not give access
+func sessionID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func NewService(
+	adminRepo AdminRepository,
+	sessionRepo SessionRepository,
+	sessionTTL time.Duration,
+	loginLimit LoginLimit,
+) (Service, error) {
+
+	if adminRepo == nil {
+		return nil, errors.New("adminauth.NewService, adminRepo cannot be empty")
+	}
+
+	if sessionRepo == nil {
+		return nil, errors.New("adminauth.NewService, sessionRepo cannot be empty")
+	}
+
+	if sessionTTL <= 0 {
+		return nil, errors.New("adminauth.NewService, sessionTTL must be positive")
+	}
+
+	if loginLimit.MaxFailures <= 0 || loginLimit.Window <= 0 {
+		return nil, errors.New("adminauth.NewService, loginLimit must be positive")
+	}
+
+	return &service{
+		adminRepo:   adminRepo,
+		sessionRepo: sessionRepo,
+		sessionTTL:  sessionTTL,
+		throttle:    newLoginThrottle(loginLimit),
+	}, nil
+}
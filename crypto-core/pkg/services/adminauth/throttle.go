@@ -0,0 +1,99 @@
+package adminauth
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LoginLimit allows MaxFailures failed logins per e-mail and per client address within sliding Window
+type LoginLimit struct {
+	MaxFailures int
+	Window      time.Duration
+}
+
+type TooManyAttemptsError struct {
+	Limit LoginLimit
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("no more than %d failed logins are allowed within %s", e.Limit.MaxFailures, e.Limit.Window)
+}
+
+// maxThrottledKeys triggers removal of stale keys, so failures from many addresses do not pile up
+const maxThrottledKeys = 10000
+
+// loginThrottle keeps failed login times in memory, every instance counts its own attempts
+type loginThrottle struct {
+	limit LoginLimit
+
+	mu       sync.Mutex
+	failures map[string][]time.Time
+}
+
+func newLoginThrottle(limit LoginLimit) *loginThrottle {
+	return &loginThrottle{
+		limit:    limit,
+		failures: make(map[string][]time.Time),
+	}
+}
+
+// loginKeys returns throttle keys of the attempt, both the account and the client are limited
+func loginKeys(email string, clientIP string) []string {
+	return []string{"email:" + strings.ToLower(strings.TrimSpace(email)), "ip:" + clientIP}
+}
+
+func (t *loginThrottle) blocked(now time.Time, keys ...string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, key := range keys {
+		if len(t.recent(now, key)) >= t.limit.MaxFailures {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *loginThrottle) fail(now time.Time, keys ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, key := range keys {
+		t.failures[key] = append(t.recent(now, key), now)
+	}
+
+	if len(t.failures) > maxThrottledKeys {
+		for key := range t.failures {
+			t.recent(now, key)
+		}
+	}
+}
+
+func (t *loginThrottle) reset(keys ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, key := range keys {
+		delete(t.failures, key)
+	}
+}
+
+// recent drops failures older than the window, keys without recent failures are removed
+func (t *loginThrottle) recent(now time.Time, key string) []time.Time {
+	since := now.Add(-t.limit.Window)
+
+	times := t.failures[key]
+	for len(times) > 0 && !times[0].After(since) {
+		times = times[1:]
+	}
+
+	if len(times) == 0 {
+		delete(t.failures, key)
+		return nil
+	}
+
+	t.failures[key] = times
+	return times
+}
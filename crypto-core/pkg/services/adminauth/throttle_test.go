@@ -0,0 +1,112 @@
+package adminauth
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLoginThrottle(t *testing.T) {
+	limit := LoginLimit{MaxFailures: 3, Window: time.Minute}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	account := loginKeys("admin@example.com", "10.0.0.1")
+	sameAccount := loginKeys(" Admin@Example.com ", "10.0.0.2")
+	sameClient := loginKeys("other@example.com", "10.0.0.1")
+	other := loginKeys("other@example.com", "10.0.0.2")
+
+	type step struct {
+		at   time.Duration
+		fail bool
+		// reset clears the account key only, as a successful login does
+		reset bool
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+		at    time.Duration
+		keys  []string
+		want  bool
+	}{
+		{name: "no failures", at: 0, keys: account, want: false},
+		{
+			name:  "below limit",
+			steps: []step{{at: 0, fail: true}, {at: time.Second, fail: true}},
+			at:    2 * time.Second, keys: account, want: false,
+		},
+		{
+			name:  "limit reached",
+			steps: []step{{at: 0, fail: true}, {at: time.Second, fail: true}, {at: 2 * time.Second, fail: true}},
+			at:    3 * time.Second, keys: account, want: true,
+		},
+		{
+			name:  "same e-mail from another address",
+			steps: []step{{at: 0, fail: true}, {at: time.Second, fail: true}, {at: 2 * time.Second, fail: true}},
+			at:    3 * time.Second, keys: sameAccount, want: true,
+		},
+		{
+			name:  "another e-mail from the same address",
+			steps: []step{{at: 0, fail: true}, {at: time.Second, fail: true}, {at: 2 * time.Second, fail: true}},
+			at:    3 * time.Second, keys: sameClient, want: true,
+		},
+		{
+			name:  "another e-mail from another address",
+			steps: []step{{at: 0, fail: true}, {at: time.Second, fail: true}, {at: 2 * time.Second, fail: true}},
+			at:    3 * time.Second, keys: other, want: false,
+		},
+		{
+			name:  "oldest failure left the window",
+			steps: []step{{at: 0, fail: true}, {at: time.Second, fail: true}, {at: 2 * time.Second, fail: true}},
+			at:    time.Minute, keys: account, want: false,
+		},
+		{
+			name:  "failure exactly window ago is dropped",
+			steps: []step{{at: 0, fail: true}, {at: 0, fail: true}, {at: 0, fail: true}},
+			at:    time.Minute, keys: account, want: false,
+		},
+		{
+			name:  "successful login resets the account",
+			steps: []step{{at: 0, fail: true}, {at: time.Second, fail: true}, {at: 2 * time.Second, fail: true}, {at: 3 * time.Second, reset: true}},
+			at:    4 * time.Second, keys: sameAccount, want: false,
+		},
+		{
+			name:  "successful login keeps the address limited",
+			steps: []step{{at: 0, fail: true}, {at: time.Second, fail: true}, {at: 2 * time.Second, fail: true}, {at: 3 * time.Second, reset: true}},
+			at:    4 * time.Second, keys: sameClient, want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			throttle := newLoginThrottle(limit)
+			for _, s := range tt.steps {
+				if s.fail {
+					throttle.fail(start.Add(s.at), account...)
+				}
+				if s.reset {
+					throttle.reset(account[0])
+				}
+			}
+
+			if got := throttle.blocked(start.Add(tt.at), tt.keys...); got != tt.want {
+				t.Errorf("got blocked %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginThrottleDropsStaleKeys(t *testing.T) {
+	throttle := newLoginThrottle(LoginLimit{MaxFailures: 3, Window: time.Minute})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < maxThrottledKeys; i++ {
+		throttle.fail(start, "ip:"+strconv.Itoa(i))
+	}
+
+	throttle.fail(start.Add(time.Hour), "ip:fresh")
+
+	if len(throttle.failures) != 1 {
+		t.Errorf("got %d keys, want only the fresh one", len(throttle.failures))
+	}
+}
@@ -0,0 +1,30 @@
+package blockchain
+
+import (
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// metricsOracleClient observes duration and result of buyback oracle requests
+type metricsOracleClient struct {
+	next BuybackOracleClient
+}
+
+func (c *metricsOracleClient) GetBuybackPrice(assetID int64) (price *decimal.Decimal, err error) {
+	defer func(start time.Time) {
+		metrics.ObserveExternalCall("buybackOracle", "GetBuybackPrice", start, err)
+	}(time.Now())
+
+	return c.next.GetBuybackPrice(assetID)
+}
+
+func NewMetricsOracleClient(next BuybackOracleClient) (BuybackOracleClient, error) {
+	if next == nil {
+		return nil, errors.New("blockchain.NewMetricsOracleClient, next cannot be empty")
+	}
+
+	return &metricsOracleClient{next: next}, nil
+}
@@ -0,0 +1,16 @@
+package cache
+
+import (
+	"time"
+)
+
+// Cache stores serialized values by key for a limited time.
+// Values are kept as bytes so in-process and external (e.g. Redis) implementations are interchangeable
+type Cache interface {
+	// Get returns value by key, second result is false when key is missing or expired
+	Get(key string) ([]byte, bool, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Delete(keys ...string) error
+	// Close releases resources of the cache, it must not be used after that
+	Close() error
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/services/cache"
+	"github.com/pkg/errors"
+)
+
+type item struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type memoryCache struct {
+	mu    sync.RWMutex
+	items map[string]item
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *memoryCache) Get(key string) ([]byte, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	it, ok := c.items[key]
+	if !ok || time.Now().After(it.expiresAt) {
+		return nil, false, nil
+	}
+
+	return it.value, true, nil
+}
+
+func (c *memoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.New("memoryCache.Set, ttl must be positive")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items[key] = item{
+		value:     value,
+		expiresAt: time.Now().Add(ttl),
+	}
+
+	return nil
+}
+
+func (c *memoryCache) Delete(keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.items, key)
+	}
+
+	return nil
+}
+
+// cleanup removes expired items, so keys that are never requested again do not stay in memory
+func (c *memoryCache) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			c.mu.Lock()
+			for key, it := range c.items {
+				if now.After(it.expiresAt) {
+					delete(c.items, key)
+				}
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+// Close stops the cleanup goroutine
+func (c *memoryCache) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+	})
+	return nil
+}
+
+func NewCache(cleanupInterval time.Duration) (cache.Cache, error) {
+	if cleanupInterval <= 0 {
+		return nil, errors.New("memory.NewCache, cleanupInterval must be positive")
+	}
+
+	c := &memoryCache{
+		items: make(map[string]item),
+		stop:  make(chan struct{}),
+	}
+	go c.cleanup(cleanupInterval)
+
+	return c, nil
+}
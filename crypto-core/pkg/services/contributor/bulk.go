@@ -0,0 +1,164 @@
+package contributor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/bfg-dev/crypto-core/pkg/services/notification"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// MaxBulkSize limits the number of requests changed by one bulk action
+const MaxBulkSize = 200
+
+// StatusUpdate is a request id with status it is expected to have before the update.
+// Reward in ATx units is credited to payout ledger when request is approved.
+// Event is written to notification outbox with the update, nil means the contributor is not notified.
+type StatusUpdate struct {
+	ID       int64
+	Expected entities.UserMissionStatus
+	Reward   decimal.Decimal
+	Event    *notification.Event
+}
+
+// RequestConflictError is returned by bulk update when request status was changed concurrently
+type RequestConflictError struct {
+	ID int64
+}
+
+func (e *RequestConflictError) Error() string {
+	return fmt.Sprintf("status of mission request %d was changed concurrently", e.ID)
+}
+
+func (e *RequestConflictError) Unwrap() error {
+	return ErrStatusConflict
+}
+
+// BulkValidationError is returned when bulk action input is rejected as a whole
+type BulkValidationError struct {
+	Message string
+}
+
+func (e *BulkValidationError) Error() string {
+	return e.Message
+}
+
+type BulkItemResult struct {
+	ID      int64  `json:"id"`
+	Applied bool   `json:"applied"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkResult reports bulk status change per request. Applied is true only if every request was changed.
+type BulkResult struct {
+	Applied bool             `json:"applied"`
+	Items   []BulkItemResult `json:"items"`
+}
+
+func (s *service) BulkSetMissionRequestStatus(ctx context.Context, ids []int64, status entities.UserMissionStatus, change StatusChange) (*BulkResult, error) {
+	if change.ActorID == 0 {
+		return nil, errors.New("contributor.BulkSetMissionRequestStatus, actor cannot be empty")
+	}
+
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 {
+		return nil, &BulkValidationError{Message: "no requests selected"}
+	}
+
+	if len(ids) > MaxBulkSize {
+		return nil, &BulkValidationError{
+			Message: fmt.Sprintf("no more than %d requests can be changed at once, %d selected", MaxBulkSize, len(ids)),
+		}
+	}
+
+	if status == StatusPaid {
+		return nil, ErrPaidThroughPayouts
+	}
+
+	if status == StatusWithdrawn {
+		return nil, ErrWithdrawnByContributor
+	}
+
+	if err := s.validateRejectionReason(status, change.Reason); err != nil {
+		return nil, err
+	}
+
+	requests, err := s.userMissionRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.BulkSetMissionRequestStatus, unable to get requests")
+	}
+
+	current := make(map[int64]*UserMission, len(requests))
+	for i := range requests {
+		current[requests[i].ID] = &requests[i]
+	}
+	rewards := make(map[int64]decimal.Decimal)
+
+	result := &BulkResult{Items: make([]BulkItemResult, len(ids))}
+	updates := make([]StatusUpdate, 0, len(ids))
+	valid := true
+
+	for i, id := range ids {
+		result.Items[i].ID = id
+
+		request, ok := current[id]
+		if !ok {
+			result.Items[i].Error = ErrUserMissionNotFound.Error()
+			valid = false
+			continue
+		}
+
+		if err = ValidateStatusTransition(request.Status, status); err != nil {
+			result.Items[i].Error = err.Error()
+			valid = false
+			continue
+		}
+
+		update, err := s.statusUpdate(ctx, request, status, rewards)
+		if err != nil {
+			return nil, errors.Wrap(err, "contributor.BulkSetMissionRequestStatus, unable to get mission reward")
+		}
+		update.Event = s.statusChangedEvent(ctx, update, status, change)
+		updates = append(updates, update)
+	}
+
+	if !valid {
+		return result, nil
+	}
+
+	err = s.userMissionRepo.SetMissionRequestStatuses(ctx, updates, status, change)
+	var conflictErr *RequestConflictError
+	if errors.As(err, &conflictErr) {
+		for i := range result.Items {
+			if result.Items[i].ID == conflictErr.ID {
+				result.Items[i].Error = conflictErr.Error()
+			}
+		}
+		return result, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.BulkSetMissionRequestStatus, unable to set requests status")
+	}
+	s.statusChanged()
+
+	result.Applied = true
+	for i := range result.Items {
+		result.Items[i].Applied = true
+	}
+	return result, nil
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
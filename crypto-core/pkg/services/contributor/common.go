@@ -1,32 +1,118 @@
 package contributor
 
 import (
+	"context"
 	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/pkg/errors"
 	"time"
 )
 
+var ErrUserMissionNotFound = errors.New("user mission not found")
+
+// UserMission is user mission request with its moderation result
+type UserMission struct {
+	entities.CCUserMission
+	RejectionReason  string `db:"rejectionReason"`
+	ModeratorComment string `db:"moderatorComment"`
+}
+
 type UserMissionRequest struct {
-	ID                       int64
-	CreatedAt                time.Time
-	UserName                 string
-	Mission                  string
-	MissionParameters        map[string]string
+	ID                   int64                      `json:"id"`
+	CreatedAt            time.Time                  `json:"createdAt"`
+	UserID               int64                      `json:"userId"`
+	// UserName is User shown by display name policy
+	UserName             string                     `json:"userName"`
+	User                 UserIdentity               `json:"-"`
+	MissionID            int64                      `json:"missionId"`
+	Mission              string                     `json:"mission"`
+	Status               entities.UserMissionStatus `json:"status"`
+	MissionParameters    map[string]string          `json:"missionParameters"`
+	// ParametersError is set when stored parameters cannot be decoded, RawParameters keep them as is
+	// for admins and are left empty for contributors
+	ParametersError      string                     `json:"parametersError,omitempty"`
+	RawParameters        string                     `json:"rawParameters,omitempty"`
+	// Parameters are MissionParameters typed and ordered by mission parameter schema
+	Parameters           []MissionParameter         `json:"parameters"`
+	RejectionReason      string                     `json:"rejectionReason"`
+	RejectionReasonTitle string                     `json:"rejectionReasonTitle"`
+	ModeratorComment     string                     `json:"moderatorComment"`
+}
+
+// StatusChange describes who changes status of a mission request and why.
+// Reason is a code from rejection reasons catalogue and is required for rejected requests only.
+type StatusChange struct {
+	ActorID int64
+	Reason  string
+	Comment string
+}
+
+type StatusHistoryEntry struct {
+	ID            int64                      `db:"id"`
+	UserMissionID int64                      `db:"userMissionId"`
+	ActorID       int64                      `db:"actorId"`
+	ActorEmail    string                     `db:"actorEmail"`
+	OldStatus     entities.UserMissionStatus `db:"oldStatus"`
+	NewStatus     entities.UserMissionStatus `db:"newStatus"`
+	Comment       string                     `db:"comment"`
+	CreatedAt     time.Time                  `db:"createdAt"`
 }
 
 type MissionRepository interface {
-	GetByID(id int64) (*entities.CCMission, error)
+	GetByID(ctx context.Context, id int64) (*Mission, error)
+	List(ctx context.Context, includeArchived bool) ([]Mission, error)
+	Create(ctx context.Context, mission *Mission) (int64, error)
+	Update(ctx context.Context, mission *Mission) error
+	Archive(ctx context.Context, id int64) error
 }
 
 type UserMissionRepository interface {
-	GetByID(id int64) (*entities.CCUserMission, error)
-	GetNewMissionRequests() ([]entities.CCUserMission, error)
-	GetNewMissionRequestsList() ([]UserMissionRequest, error)
-	SetMissionRequestStatus(id int64, status entities.UserMissionStatus) error
+	GetByID(ctx context.Context, id int64) (*UserMission, error)
+	// ListMissionRequests returns up to filter.Limit requests after filter.After cursor
+	ListMissionRequests(ctx context.Context, filter ListFilter) ([]UserMissionRequest, error)
+	// SetMissionRequestStatus moves request from update.Expected status to status, stores moderation
+	// result, writes status history and credits payout of approved request in one transaction.
+	// Returns ErrStatusConflict if request is not in expected status.
+	SetMissionRequestStatus(ctx context.Context, update StatusUpdate, status entities.UserMissionStatus, change StatusChange) error
+	GetStatusHistory(ctx context.Context, id int64) ([]StatusHistoryEntry, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]UserMission, error)
+	// SetMissionRequestStatuses applies all updates in one transaction. Returns *RequestConflictError
+	// and applies nothing if any request is not in its expected status.
+	SetMissionRequestStatuses(ctx context.Context, updates []StatusUpdate, status entities.UserMissionStatus, change StatusChange) error
+	// CreateSubmission inserts new request unless it duplicates an earlier not rejected nor withdrawn
+	// one of the same user (ErrDuplicateSubmission) or the user exceeded limit (*RateLimitError). Submissions of one user
+	// are serialized, so concurrent requests cannot bypass either check.
+	CreateSubmission(ctx context.Context, request *UserMission, limit SubmissionLimit) (int64, error)
 }
 
 type Service interface {
-	GetNewMissionRequests() ([]entities.CCUserMission, error)
-	GetNewMissionRequestsList() ([]UserMissionRequest, error)
-	SetMissionRequestStatus(id int64, status entities.UserMissionStatus) error
+	ListMissionRequests(ctx context.Context, filter ListFilter) (*ListPage, error)
+	SetMissionRequestStatus(ctx context.Context, id int64, status entities.UserMissionStatus, change StatusChange) error
+	GetMissionRequestStatusHistory(ctx context.Context, id int64) ([]StatusHistoryEntry, error)
+	// BulkSetMissionRequestStatus changes status of all requests or none of them
+	BulkSetMissionRequestStatus(ctx context.Context, ids []int64, status entities.UserMissionStatus, change StatusChange) (*BulkResult, error)
+	RejectionReasons() []RejectionReason
+
+	ListMissions(ctx context.Context, includeArchived bool) ([]Mission, error)
+	GetMission(ctx context.Context, id int64) (*Mission, error)
+	CreateMission(ctx context.Context, in MissionInput) (*Mission, error)
+	UpdateMission(ctx context.Context, id int64, in MissionInput) (*Mission, error)
+	// ArchiveMission hides mission from contributors, its requests are kept
+	ArchiveMission(ctx context.Context, id int64) error
+
+	// ListActiveMissions returns missions contributors can submit now
+	ListActiveMissions(ctx context.Context) ([]Mission, error)
+	// Submit creates new request of the user for the mission
+	Submit(ctx context.Context, userID int64, missionID int64, params map[string]string) (*UserMissionRequest, error)
+	// ListSubmissions lists requests of the user, filter.UserID is ignored. RawParameters are left empty
+	ListSubmissions(ctx context.Context, userID int64, filter ListFilter) (*ListPage, error)
+	// GetSubmission returns ErrUserMissionNotFound if request does not belong to the user,
+	// RawParameters are left empty
+	GetSubmission(ctx context.Context, userID int64, id int64) (*UserMissionRequest, error)
+	// WithdrawSubmission cancels request of the user which is not moderated yet
+	WithdrawSubmission(ctx context.Context, userID int64, id int64) error
+
+	// OnStatusChanged registers listener called after statuses of requests are changed,
+	// listeners are registered before the service is used
+	OnStatusChanged(listener func())
 }
 
@@ -0,0 +1,100 @@
+package contributor
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// DisplayNamePolicy decides how a user is shown in lists. Every policy falls back to
+// whatever is filled when preferred fields are empty, and to user id at last.
+type DisplayNamePolicy string
+
+const (
+	// NamePolicyFullName shows "First Last", e-mail when both names are empty
+	NamePolicyFullName DisplayNamePolicy = "full_name"
+	// NamePolicyShortName shows "First L.", e-mail when both names are empty
+	NamePolicyShortName DisplayNamePolicy = "short_name"
+	// NamePolicyEmail shows e-mail, full name when e-mail is empty
+	NamePolicyEmail DisplayNamePolicy = "email"
+)
+
+// UserIdentity is projection of "users" row, every field but ID may be NULL
+type UserIdentity struct {
+	ID        int64   `db:"id"`
+	FirstName *string `db:"firstname"`
+	LastName  *string `db:"lastname"`
+	Email     *string `db:"email"`
+}
+
+// ParseDisplayNamePolicy returns NamePolicyFullName for empty value
+func ParseDisplayNamePolicy(value string) (DisplayNamePolicy, error) {
+	policy := DisplayNamePolicy(strings.TrimSpace(value))
+	switch policy {
+	case "":
+		return NamePolicyFullName, nil
+	case NamePolicyFullName, NamePolicyShortName, NamePolicyEmail:
+		return policy, nil
+	}
+	return "", errors.Errorf("unknown display name policy %q", value)
+}
+
+// DisplayName returns name of the user by policy, maskEmail hides e-mail used as a name
+func (p DisplayNamePolicy) DisplayName(user UserIdentity, maskEmail bool) string {
+	email := trimmed(user.Email)
+	if email != "" && maskEmail {
+		email = MaskEmail(email)
+	}
+
+	name := user.fullName()
+	if p == NamePolicyShortName {
+		name = user.shortName()
+	}
+
+	candidates := []string{name, email}
+	if p == NamePolicyEmail {
+		candidates = []string{email, name}
+	}
+
+	for _, candidate := range candidates {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return fmt.Sprintf("Пользователь #%d", user.ID)
+}
+
+func (u UserIdentity) fullName() string {
+	return strings.TrimSpace(trimmed(u.FirstName) + " " + trimmed(u.LastName))
+}
+
+// shortName abbreviates last name to its first letter, name without first name stays full
+func (u UserIdentity) shortName() string {
+	first, last := trimmed(u.FirstName), trimmed(u.LastName)
+	if first == "" || last == "" {
+		return u.fullName()
+	}
+
+	initial, _ := utf8.DecodeRuneInString(last)
+	return first + " " + string(initial) + "."
+}
+
+// MaskEmail keeps first letter of the local part and the domain, e.g. "i***@example.com"
+func MaskEmail(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 {
+		return "***"
+	}
+
+	first, _ := utf8.DecodeRuneInString(email)
+	return string(first) + "***" + email[at:]
+}
+
+func trimmed(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return strings.TrimSpace(*value)
+}
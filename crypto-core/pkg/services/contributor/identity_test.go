@@ -0,0 +1,76 @@
+package contributor
+
+import "testing"
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func TestDisplayName(t *testing.T) {
+	empty := UserIdentity{ID: 7}
+	first := UserIdentity{ID: 7, FirstName: stringPtr(" Иван ")}
+	last := UserIdentity{ID: 7, LastName: stringPtr("Петров")}
+	full := UserIdentity{ID: 7, FirstName: stringPtr("Иван"), LastName: stringPtr("Петров")}
+	email := UserIdentity{ID: 7, Email: stringPtr("ivan@example.com")}
+	blank := UserIdentity{ID: 7, FirstName: stringPtr(" "), LastName: stringPtr(""), Email: stringPtr("  ")}
+	all := UserIdentity{ID: 7, FirstName: stringPtr("Иван"), LastName: stringPtr("Петров"), Email: stringPtr("ivan@example.com")}
+
+	tests := []struct {
+		name      string
+		policy    DisplayNamePolicy
+		user      UserIdentity
+		maskEmail bool
+		want      string
+	}{
+		{"full name, nothing filled", NamePolicyFullName, empty, false, "Пользователь #7"},
+		{"full name, blank fields", NamePolicyFullName, blank, false, "Пользователь #7"},
+		{"full name, first name only", NamePolicyFullName, first, false, "Иван"},
+		{"full name, last name only", NamePolicyFullName, last, false, "Петров"},
+		{"full name", NamePolicyFullName, all, false, "Иван Петров"},
+		{"full name, e-mail fallback", NamePolicyFullName, email, false, "ivan@example.com"},
+		{"full name, masked e-mail fallback", NamePolicyFullName, email, true, "i***@example.com"},
+
+		{"short name, nothing filled", NamePolicyShortName, empty, false, "Пользователь #7"},
+		{"short name, first name only", NamePolicyShortName, first, false, "Иван"},
+		{"short name, last name only", NamePolicyShortName, last, false, "Петров"},
+		{"short name", NamePolicyShortName, full, false, "Иван П."},
+		{"short name, masked e-mail fallback", NamePolicyShortName, email, true, "i***@example.com"},
+
+		{"email, nothing filled", NamePolicyEmail, empty, false, "Пользователь #7"},
+		{"email", NamePolicyEmail, all, false, "ivan@example.com"},
+		{"email, masked", NamePolicyEmail, all, true, "i***@example.com"},
+		{"email, name fallback", NamePolicyEmail, full, true, "Иван Петров"},
+		{"email, blank fields", NamePolicyEmail, blank, true, "Пользователь #7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.DisplayName(tt.user, tt.maskEmail); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{"ivan@example.com", "i***@example.com"},
+		{"i@example.com", "i***@example.com"},
+		{"иван@пример.рф", "и***@пример.рф"},
+		{"a@b@example.com", "a***@example.com"},
+		{"@example.com", "***"},
+		{"ivan", "***"},
+		{"", "***"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			if got := MaskEmail(tt.email); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package contributor
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+type SortOrder string
+
+const (
+	SortCreatedAtDesc SortOrder = "created_desc"
+	SortCreatedAtAsc  SortOrder = "created_asc"
+)
+
+var ErrInvalidCursor = errors.New("invalid list cursor")
+
+// ListCursor points to the last row of previous page, rows are ordered by (createdAt, id)
+type ListCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// ListFilter describes mission requests listing. Zero values mean no filtering.
+type ListFilter struct {
+	Status      entities.UserMissionStatus
+	MissionID   int64
+	UserID      int64
+	CreatedFrom time.Time
+	// CreatedTo is exclusive upper bound of createdAt
+	CreatedTo time.Time
+	Sort      SortOrder
+	Limit     int
+	After     *ListCursor
+	// MaskEmails hides e-mails used as user names, set for readers not allowed to see them
+	MaskEmails bool
+}
+
+type ListPage struct {
+	Requests   []UserMissionRequest `json:"requests"`
+	NextCursor string               `json:"nextCursor,omitempty"`
+}
+
+// Normalize fills defaults and checks filter values
+func (f *ListFilter) Normalize() error {
+	if f.Status != "" {
+		if _, ok := statusTransitions[f.Status]; !ok {
+			return &UnknownStatusError{Status: f.Status}
+		}
+	}
+
+	switch f.Sort {
+	case "":
+		f.Sort = SortCreatedAtDesc
+	case SortCreatedAtDesc, SortCreatedAtAsc:
+	default:
+		return errors.Errorf("unknown sort order %q", f.Sort)
+	}
+
+	if f.Limit == 0 {
+		f.Limit = DefaultListLimit
+	}
+
+	if f.Limit < 0 || f.Limit > MaxListLimit {
+		return errors.Errorf("limit should be between 1 and %d", MaxListLimit)
+	}
+
+	if !f.CreatedFrom.IsZero() && !f.CreatedTo.IsZero() && !f.CreatedFrom.Before(f.CreatedTo) {
+		return errors.New("created-at range is empty")
+	}
+
+	return nil
+}
+
+func (c ListCursor) String() string {
+	raw := fmt.Sprintf("%d:%d", c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseListCursor decodes cursor returned as ListPage.NextCursor
+func ParseListCursor(value string) (*ListCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &ListCursor{CreatedAt: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
@@ -0,0 +1,76 @@
+package contributor
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestListCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor ListCursor
+	}{
+		{"zero", ListCursor{CreatedAt: time.Unix(0, 0).UTC()}},
+		{"nanoseconds", ListCursor{CreatedAt: time.Date(2024, 3, 1, 10, 20, 30, 123456789, time.UTC), ID: 42}},
+		{"before epoch", ListCursor{CreatedAt: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), ID: 1}},
+		{"big id", ListCursor{CreatedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), ID: 9223372036854775807}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseListCursor(tt.cursor.String())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.CreatedAt.Equal(tt.cursor.CreatedAt) || got.ID != tt.cursor.ID {
+				t.Errorf("got %+v, want %+v", *got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestListCursorTimeZone(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	cursor := ListCursor{CreatedAt: time.Date(2024, 3, 1, 13, 0, 0, 0, moscow), ID: 7}
+
+	got, err := ParseListCursor(cursor.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC); got.CreatedAt != want {
+		t.Errorf("got %v, want %v", got.CreatedAt, want)
+	}
+}
+
+func TestParseListCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"empty", ""},
+		{"not base64", "!!!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte("1:23"))},
+		{"no separator", encode("12")},
+		{"empty time", encode(":2")},
+		{"empty id", encode("1:")},
+		{"time not a number", encode("now:2")},
+		{"id not a number", encode("1:two")},
+		{"extra part", encode("1:2:3")},
+		{"time overflow", encode("99999999999999999999:2")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseListCursor(tt.value)
+			if err != ErrInvalidCursor {
+				t.Errorf("got %+v, %v, want ErrInvalidCursor", got, err)
+			}
+		})
+	}
+}
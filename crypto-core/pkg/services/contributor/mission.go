@@ -0,0 +1,176 @@
+package contributor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrMissionNotFound = errors.New("mission not found")
+	ErrMissionArchived = errors.New("mission is archived")
+)
+
+// Mission is an entry of missions catalogue
+type Mission struct {
+	ID              int64           `db:"id" json:"id"`
+	Title           string          `db:"title" json:"title"`
+	Description     string          `db:"description" json:"description"`
+	Reward          decimal.Decimal `db:"reward" json:"reward"`
+	ParameterSchema ParameterSchema `db:"parameterSchema" json:"parameterSchema"`
+	ActiveFrom      *time.Time      `db:"activeFrom" json:"activeFrom"`
+	ActiveTo        *time.Time      `db:"activeTo" json:"activeTo"`
+	ArchivedAt      *time.Time      `db:"archivedAt" json:"archivedAt"`
+	UpdatedAt       time.Time       `db:"updatedAt" json:"updatedAt"`
+}
+
+// IsActive reports whether contributors can submit the mission at moment t
+func (m *Mission) IsActive(t time.Time) bool {
+	if m.ArchivedAt != nil {
+		return false
+	}
+	if m.ActiveFrom != nil && t.Before(*m.ActiveFrom) {
+		return false
+	}
+	if m.ActiveTo != nil && !t.Before(*m.ActiveTo) {
+		return false
+	}
+	return true
+}
+
+// MissionInput holds editable mission fields
+type MissionInput struct {
+	Title           string          `json:"title"`
+	Description     string          `json:"description"`
+	Reward          decimal.Decimal `json:"reward"`
+	ParameterSchema ParameterSchema `json:"parameterSchema"`
+	ActiveFrom      *time.Time      `json:"activeFrom"`
+	ActiveTo        *time.Time      `json:"activeTo"`
+}
+
+type MissionValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *MissionValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Validate trims input and checks it
+func (in *MissionInput) Validate() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if in.Title == "" {
+		return &MissionValidationError{Field: "title", Message: "cannot be empty"}
+	}
+
+	if in.Reward.IsNegative() {
+		return &MissionValidationError{Field: "reward", Message: "cannot be negative"}
+	}
+
+	if in.ActiveFrom != nil && in.ActiveTo != nil && !in.ActiveFrom.Before(*in.ActiveTo) {
+		return &MissionValidationError{Field: "activeTo", Message: "should be later than activeFrom"}
+	}
+
+	if err := in.ParameterSchema.validateSchema(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) ListMissions(ctx context.Context, includeArchived bool) ([]Mission, error) {
+	missions, err := s.missionRepo.List(ctx, includeArchived)
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.ListMissions, unable to get missions")
+	}
+	return missions, nil
+}
+
+func (s *service) GetMission(ctx context.Context, id int64) (*Mission, error) {
+	mission, err := s.missionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.GetMission, unable to get mission")
+	}
+
+	if mission == nil {
+		return nil, ErrMissionNotFound
+	}
+	return mission, nil
+}
+
+func (s *service) CreateMission(ctx context.Context, in MissionInput) (*Mission, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	mission := missionFromInput(Mission{}, in)
+	id, err := s.missionRepo.Create(ctx, &mission)
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.CreateMission, unable to create mission")
+	}
+
+	return s.GetMission(ctx, id)
+}
+
+func (s *service) UpdateMission(ctx context.Context, id int64, in MissionInput) (*Mission, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	mission, err := s.GetMission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if mission.ArchivedAt != nil {
+		return nil, ErrMissionArchived
+	}
+
+	updated := missionFromInput(*mission, in)
+	err = s.missionRepo.Update(ctx, &updated)
+	if err == ErrMissionNotFound {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.UpdateMission, unable to update mission")
+	}
+
+	return s.GetMission(ctx, id)
+}
+
+func (s *service) ArchiveMission(ctx context.Context, id int64) error {
+	mission, err := s.GetMission(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if mission.ArchivedAt != nil {
+		return ErrMissionArchived
+	}
+
+	err = s.missionRepo.Archive(ctx, id)
+	if err == ErrMissionNotFound {
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "contributor.ArchiveMission, unable to archive mission")
+	}
+	return nil
+}
+
+func missionFromInput(mission Mission, in MissionInput) Mission {
+	mission.Title = in.Title
+	mission.Description = in.Description
+	mission.Reward = in.Reward
+	mission.ParameterSchema = in.ParameterSchema
+	mission.ActiveFrom = in.ActiveFrom
+	mission.ActiveTo = in.ActiveTo
+	return mission
+}
@@ -0,0 +1,286 @@
+package contributor
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type ParameterType string
+
+const (
+	ParameterURL    ParameterType = "url"
+	ParameterText   ParameterType = "text"
+	ParameterNumber ParameterType = "number"
+	ParameterWallet ParameterType = "wallet"
+	ParameterEmail  ParameterType = "email"
+)
+
+// ParameterTypes lists supported parameter types in the order they are offered to admins
+var ParameterTypes = []ParameterType{ParameterURL, ParameterText, ParameterNumber, ParameterWallet, ParameterEmail}
+
+// maxTextParameterLength limits length of every submitted parameter value
+const maxTextParameterLength = 2048
+
+var walletAddressRe = regexp.MustCompile(`^(0x[0-9a-fA-F]{40}|[13][a-km-zA-HJ-NP-Z1-9]{25,34}|bc1[a-z0-9]{39,59})$`)
+
+// ParameterSpec describes one parameter of mission submission
+type ParameterSpec struct {
+	Name     string        `json:"name"`
+	Type     ParameterType `json:"type"`
+	Required bool          `json:"required"`
+}
+
+// ParameterSchema is stored as json array in text column
+type ParameterSchema []ParameterSpec
+
+// MissionParameter is submitted parameter value with its type, Type is empty for values missing in schema
+type MissionParameter struct {
+	Name  string        `json:"name"`
+	Type  ParameterType `json:"type"`
+	Value string        `json:"value"`
+}
+
+// ParametersValidationError maps parameter name to its problem
+type ParametersValidationError struct {
+	Errors map[string]string
+}
+
+func (e *ParametersValidationError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	problems := make([]string, len(names))
+	for i, name := range names {
+		problems[i] = fmt.Sprintf("%s: %s", name, e.Errors[name])
+	}
+	return "wrong mission parameters: " + strings.Join(problems, "; ")
+}
+
+func (t ParameterType) IsValid() bool {
+	for _, known := range ParameterTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSchema checks schema declared by admin
+func (schema ParameterSchema) validateSchema() error {
+	seen := make(map[string]bool, len(schema))
+	for i, spec := range schema {
+		name := strings.TrimSpace(spec.Name)
+		if name == "" {
+			return &MissionValidationError{Field: "parameterSchema", Message: "parameter name cannot be empty"}
+		}
+		if seen[name] {
+			return &MissionValidationError{Field: "parameterSchema", Message: fmt.Sprintf("duplicate parameter %q", name)}
+		}
+		if !spec.Type.IsValid() {
+			return &MissionValidationError{Field: "parameterSchema", Message: fmt.Sprintf("unknown type %q of parameter %q", spec.Type, name)}
+		}
+		seen[name] = true
+		schema[i].Name = name
+	}
+	return nil
+}
+
+// Validate checks submitted parameters, unknown parameters are not allowed
+func (schema ParameterSchema) Validate(params map[string]string) error {
+	problems := make(map[string]string)
+
+	specs := make(map[string]ParameterSpec, len(schema))
+	for _, spec := range schema {
+		specs[spec.Name] = spec
+
+		value := strings.TrimSpace(params[spec.Name])
+		if value == "" {
+			if spec.Required {
+				problems[spec.Name] = "required"
+			}
+			continue
+		}
+
+		if err := validateParameterValue(spec.Type, value); err != nil {
+			problems[spec.Name] = err.Error()
+		}
+	}
+
+	for name := range params {
+		if _, ok := specs[name]; !ok {
+			problems[name] = "unknown parameter"
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ParametersValidationError{Errors: problems}
+	}
+	return nil
+}
+
+// Describe orders submitted values by schema, values missing in schema go last without type
+func (schema ParameterSchema) Describe(params map[string]string) []MissionParameter {
+	described := make([]MissionParameter, 0, len(params))
+	seen := make(map[string]bool, len(schema))
+
+	for _, spec := range schema {
+		seen[spec.Name] = true
+		if value, ok := params[spec.Name]; ok {
+			described = append(described, MissionParameter{Name: spec.Name, Type: spec.Type, Value: value})
+		}
+	}
+
+	extra := make([]string, 0)
+	for name := range params {
+		if !seen[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+
+	for _, name := range extra {
+		described = append(described, MissionParameter{Name: name, Value: params[name]})
+	}
+	return described
+}
+
+// IsLink reports whether parameter is safe to render as a link
+func (p MissionParameter) IsLink() bool {
+	return p.Type == ParameterURL && validateParameterValue(ParameterURL, p.Value) == nil
+}
+
+func validateParameterValue(t ParameterType, value string) error {
+	if len(value) > maxTextParameterLength {
+		return errors.Errorf("should be not longer than %d characters", maxTextParameterLength)
+	}
+
+	switch t {
+	case ParameterURL:
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("should be an http or https url")
+		}
+	case ParameterNumber:
+		if _, err := decimal.NewFromString(value); err != nil {
+			return errors.New("should be a number")
+		}
+	case ParameterWallet:
+		if !walletAddressRe.MatchString(value) {
+			return errors.New("should be a wallet address")
+		}
+	case ParameterEmail:
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return errors.New("should be an e-mail")
+		}
+	case ParameterText:
+	default:
+		return errors.Errorf("unknown parameter type %q", t)
+	}
+	return nil
+}
+
+func (schema ParameterSchema) Value() (driver.Value, error) {
+	if schema == nil {
+		return "[]", nil
+	}
+	raw, err := json.Marshal([]ParameterSpec(schema))
+	return string(raw), err
+}
+
+func (schema *ParameterSchema) Scan(src interface{}) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*schema = ParameterSchema{}
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return errors.Errorf("ParameterSchema.Scan, unsupported type %T", src)
+	}
+	return json.Unmarshal(raw, (*[]ParameterSpec)(schema))
+}
+
+// DecodeMissionParameters reads parameters stored in "missionParameters" column. NULL, empty and
+// JSON null mean no parameters. Numbers and booleans keep their JSON text, nested objects and arrays
+// are kept as canonical JSON with sorted keys, null values are dropped.
+func DecodeMissionParameters(raw *string) (map[string]string, error) {
+	params := make(map[string]string)
+	if raw == nil {
+		return params, nil
+	}
+
+	trimmed := bytes.TrimSpace([]byte(*raw))
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return params, nil
+	}
+
+	values := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(trimmed, &values); err != nil {
+		return params, errors.Wrap(err, "mission parameters should be a json object")
+	}
+
+	for name, value := range values {
+		var text string
+		switch {
+		case bytes.Equal(value, []byte("null")):
+			continue
+		case json.Unmarshal(value, &text) == nil:
+			params[name] = text
+		default:
+			canonical, err := canonicalJSON(value)
+			if err != nil {
+				return params, errors.Wrapf(err, "wrong value of parameter %q", name)
+			}
+			params[name] = canonical
+		}
+	}
+	return params, nil
+}
+
+// canonicalJSON re-encodes value with sorted object keys and without insignificant spaces,
+// so equal values stored with other formatting or key order compare equal. Numbers keep their text
+func canonicalJSON(value json.RawMessage) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return "", err
+	}
+
+	canonical := bytes.Buffer{}
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(decoded); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(canonical.String(), "\n"), nil
+}
+
+// DecodeParameters fills MissionParameters from the stored value. Undecodable value does not fail
+// the request, it is flagged with ParametersError and kept in RawParameters instead.
+func (r *UserMissionRequest) DecodeParameters(raw *string) {
+	params, err := DecodeMissionParameters(raw)
+	r.MissionParameters = params
+	if err != nil {
+		r.ParametersError = err.Error()
+		r.RawParameters = *raw
+	}
+}
@@ -0,0 +1,93 @@
+package contributor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeMissionParameters(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     *string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "NULL", raw: nil, want: map[string]string{}},
+		{name: "empty", raw: stringPtr(""), want: map[string]string{}},
+		{name: "spaces", raw: stringPtr(" \n\t"), want: map[string]string{}},
+		{name: "json null", raw: stringPtr("null"), want: map[string]string{}},
+		{name: "json null with spaces", raw: stringPtr(" null "), want: map[string]string{}},
+		{name: "empty object", raw: stringPtr("{}"), want: map[string]string{}},
+		{
+			name: "strings",
+			raw:  stringPtr(`{"url": "https://example.com/?a=1&b=2", "note": ""}`),
+			want: map[string]string{"url": "https://example.com/?a=1&b=2", "note": ""},
+		},
+		{
+			name: "numbers keep their text",
+			raw:  stringPtr(`{"count": 3, "share": 1.50, "big": 12345678901234567890, "exp": 1e3, "negative": -0.5}`),
+			want: map[string]string{"count": "3", "share": "1.50", "big": "12345678901234567890", "exp": "1e3", "negative": "-0.5"},
+		},
+		{
+			name: "booleans",
+			raw:  stringPtr(`{"verified": true, "public": false}`),
+			want: map[string]string{"verified": "true", "public": "false"},
+		},
+		{
+			name: "null values are dropped",
+			raw:  stringPtr(`{"url": "https://example.com", "comment": null}`),
+			want: map[string]string{"url": "https://example.com"},
+		},
+		{
+			name: "nested object keys are sorted",
+			raw:  stringPtr(`{"links": { "b": 2, "a": {"d": [1, 2], "c": "<x>"} }}`),
+			want: map[string]string{"links": `{"a":{"c":"<x>","d":[1,2]},"b":2}`},
+		},
+		{
+			name: "nested array",
+			raw:  stringPtr(`{"tags": [ "a", 1.0, true, null, {"z": 1, "y": 2} ]}`),
+			want: map[string]string{"tags": `["a",1.0,true,null,{"y":2,"z":1}]`},
+		},
+		{name: "malformed", raw: stringPtr(`{"url": `), wantErr: true},
+		{name: "not json", raw: stringPtr("url=https://example.com"), wantErr: true},
+		{name: "array", raw: stringPtr(`["https://example.com"]`), wantErr: true},
+		{name: "string", raw: stringPtr(`"https://example.com"`), wantErr: true},
+		{name: "number", raw: stringPtr("42"), wantErr: true},
+		{name: "trailing data", raw: stringPtr(`{"url": "a"} {"url": "b"}`), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeMissionParameters(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %v, want error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeMissionParametersEqualValues(t *testing.T) {
+	a, err := DecodeMissionParameters(stringPtr(`{"links": {"a": 1, "b": [true, {"d": "x", "c": null}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := DecodeMissionParameters(stringPtr("{\n  \"links\": {\"b\": [ true, {\"c\": null, \"d\": \"x\"} ], \"a\": 1}\n}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("values differing in formatting and key order decoded differently: %v and %v", a, b)
+	}
+}
@@ -0,0 +1,80 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/bfg-dev/crypto-core/pkg/testutil"
+)
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func TestListMissionRequestsIdentityProjection(t *testing.T) {
+	db := testutil.DB(t)
+
+	users := []contributor.UserIdentity{
+		{},
+		{FirstName: stringPtr("Иван")},
+		{LastName: stringPtr("Петров")},
+		{Email: stringPtr("ivan@example.com")},
+		{FirstName: stringPtr("Иван"), LastName: stringPtr("Петров")},
+	}
+	missionID := testutil.CreateMission(t, db, "identity projection test")
+	for i := range users {
+		users[i].ID = testutil.CreateUser(t, db, users[i].FirstName, users[i].LastName, users[i].Email)
+		testutil.CreateRequest(t, db, users[i].ID, missionID, contributor.StatusNew)
+	}
+
+	repo, err := NewUserMissionRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests, err := repo.ListMissionRequests(context.Background(), contributor.ListFilter{
+		MissionID: missionID,
+		Sort:      contributor.SortCreatedAtAsc,
+		Limit:     len(users),
+	})
+	if err != nil {
+		t.Fatalf("ListMissionRequests: %v", err)
+	}
+
+	if len(requests) != len(users) {
+		t.Fatalf("got %d requests, want %d", len(requests), len(users))
+	}
+
+	fallback := "Пользователь #"
+	tests := []struct {
+		policy contributor.DisplayNamePolicy
+		want   []string
+	}{
+		{contributor.NamePolicyFullName, []string{fallback, "Иван", "Петров", "ivan@example.com", "Иван Петров"}},
+		{contributor.NamePolicyShortName, []string{fallback, "Иван", "Петров", "ivan@example.com", "Иван П."}},
+		{contributor.NamePolicyEmail, []string{fallback, "Иван", "Петров", "ivan@example.com", "Иван Петров"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			for i, request := range requests {
+				if request.User.ID != users[i].ID {
+					t.Fatalf("request %d: got user %d, want %d", i, request.User.ID, users[i].ID)
+				}
+
+				want := tt.want[i]
+				if want == fallback {
+					want = fallback + strconv.FormatInt(users[i].ID, 10)
+				}
+
+				if got := tt.policy.DisplayName(request.User, false); got != want {
+					t.Errorf("request %d: got name %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
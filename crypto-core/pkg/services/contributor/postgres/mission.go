@@ -1,22 +1,34 @@
 package postgres
 
 import (
-	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"context"
+	"time"
+
 	"github.com/bfg-dev/crypto-core/pkg/helpers/db"
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
 	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+const missionColumns = `id, title, description, reward, "parameterSchema", "activeFrom", "activeTo", "archivedAt", "updatedAt"`
+
 type missionRepository struct {
-	db sqlx.Ext
+	db sqlx.ExtContext
 }
 
-func (repo *missionRepository) GetByID(id int64) (*entities.CCMission, error) {
-	rs := entities.CCMission{}
-	row := repo.db.QueryRowx(`SELECT * FROM "ccMissions" WHERE "id" = $1`, id)
+func (repo *missionRepository) GetByID(ctx context.Context, id int64) (_ *contributor.Mission, err error) {
+	defer metrics.ObserveDBQuery("missionRepository.GetByID", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "missionRepository.GetByID", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+	rs := contributor.Mission{}
+	row := repo.db.QueryRowxContext(ctx, `SELECT `+missionColumns+` FROM "ccMissions" WHERE "id" = $1`, id)
 
-	err := row.StructScan(&rs)
+	err = row.StructScan(&rs)
 	if err != nil {
 		return nil, db.EmptyOrError(err, "missionRepository.GetByID, unable to get mission by id")
 	}
@@ -24,6 +36,123 @@ func (repo *missionRepository) GetByID(id int64) (*entities.CCMission, error) {
 	return &rs, nil
 }
 
+func (repo *missionRepository) List(ctx context.Context, includeArchived bool) (_ []contributor.Mission, err error) {
+	defer metrics.ObserveDBQuery("missionRepository.List", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "missionRepository.List", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	missions := make([]contributor.Mission, 0)
+	err = sqlx.SelectContext(ctx, repo.db, &missions, `
+		SELECT `+missionColumns+`
+		FROM
+			"ccMissions"
+		WHERE
+			$1 OR "archivedAt" IS NULL
+		ORDER BY
+			id DESC`, includeArchived)
+	if err != nil {
+		return nil, errors.Wrap(err, "missionRepository.List, unable to get missions")
+	}
+
+	return missions, nil
+}
+
+func (repo *missionRepository) Create(ctx context.Context, mission *contributor.Mission) (_ int64, err error) {
+	defer metrics.ObserveDBQuery("missionRepository.Create", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "missionRepository.Create", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	var id int64
+	err = repo.db.QueryRowxContext(ctx, `
+		INSERT INTO
+			"ccMissions" (title, description, reward, "parameterSchema", "activeFrom", "activeTo")
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		mission.Title, mission.Description, mission.Reward, mission.ParameterSchema,
+		mission.ActiveFrom, mission.ActiveTo,
+	).Scan(&id)
+	if err != nil {
+		return 0, errors.Wrap(err, "missionRepository.Create, unable to insert mission")
+	}
+
+	return id, nil
+}
+
+func (repo *missionRepository) Update(ctx context.Context, mission *contributor.Mission) (err error) {
+	defer metrics.ObserveDBQuery("missionRepository.Update", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "missionRepository.Update", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	res, err := repo.db.ExecContext(ctx, `
+		UPDATE
+			"ccMissions"
+		SET
+			title=$2,
+			description=$3,
+			reward=$4,
+			"parameterSchema"=$5,
+			"activeFrom"=$6,
+			"activeTo"=$7,
+			"updatedAt"=now()
+		WHERE
+			id=$1`,
+		mission.ID, mission.Title, mission.Description, mission.Reward, mission.ParameterSchema,
+		mission.ActiveFrom, mission.ActiveTo,
+	)
+	if err != nil {
+		return errors.Wrap(err, "missionRepository.Update, unable to update mission")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "missionRepository.Update, unable to get affected rows")
+	}
+
+	if affected == 0 {
+		return contributor.ErrMissionNotFound
+	}
+
+	return nil
+}
+
+func (repo *missionRepository) Archive(ctx context.Context, id int64) (err error) {
+	defer metrics.ObserveDBQuery("missionRepository.Archive", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "missionRepository.Archive", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	res, err := repo.db.ExecContext(ctx, `
+		UPDATE
+			"ccMissions"
+		SET
+			"archivedAt"=now(),
+			"updatedAt"=now()
+		WHERE
+			id=$1 AND "archivedAt" IS NULL`, id)
+	if err != nil {
+		return errors.Wrap(err, "missionRepository.Archive, unable to archive mission")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "missionRepository.Archive, unable to get affected rows")
+	}
+
+	//mission is deleted or archived concurrently
+	if affected == 0 {
+		return contributor.ErrMissionNotFound
+	}
+
+	return nil
+}
 
 func NewMissionRepository(db *sqlx.DB) (contributor.MissionRepository, error) {
 	if db == nil {
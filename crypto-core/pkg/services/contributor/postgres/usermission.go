@@ -1,23 +1,40 @@
 package postgres
 
 import (
+	"context"
 	"github.com/bfg-dev/crypto-core/pkg/entities"
 	"github.com/bfg-dev/crypto-core/pkg/helpers/db"
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
 	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	notificationPostgres "github.com/bfg-dev/crypto-core/pkg/services/notification/postgres"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
-	"encoding/json"
+	"go.opentelemetry.io/otel/attribute"
+	"time"
+	"fmt"
+	"reflect"
+	"strings"
 )
 
+// submissionLockNamespace is the first key of advisory locks taken on user submissions,
+// so they do not collide with one-key locks or locks of other features
+const submissionLockNamespace = 0x63637375
+
 type userMissionRepository struct {
-	db sqlx.Ext
+	db *sqlx.DB
 }
 
-func (repo *userMissionRepository) GetByID(id int64) (*entities.CCUserMission, error) {
-	rs := entities.CCUserMission{}
-	row := repo.db.QueryRowx(`SELECT * FROM "ccUserMissions" WHERE "id" = $1`, id)
+func (repo *userMissionRepository) GetByID(ctx context.Context, id int64) (_ *contributor.UserMission, err error) {
+	defer metrics.ObserveDBQuery("userMissionRepository.GetByID", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "userMissionRepository.GetByID", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+	rs := contributor.UserMission{}
+	row := repo.db.QueryRowxContext(ctx, `SELECT * FROM "ccUserMissions" WHERE "id" = $1`, id)
 
-	err := row.StructScan(&rs)
+	err = row.StructScan(&rs)
 	if err != nil {
 		return nil, db.EmptyOrError(err, "missionRepository.GetByID, unable to get mission by id")
 	}
@@ -25,91 +42,373 @@ func (repo *userMissionRepository) GetByID(id int64) (*entities.CCUserMission, e
 	return &rs, nil
 }
 
-func (repo *userMissionRepository) GetNewMissionRequests() ([]entities.CCUserMission, error) {
-
-	rows, err := repo.db.Queryx(`SELECT * FROM "ccUserMissions" WHERE "status" = 'new'`)
+func (repo *userMissionRepository) ListMissionRequests(ctx context.Context, filter contributor.ListFilter) (_ []contributor.UserMissionRequest, err error) {
+	defer metrics.ObserveDBQuery("userMissionRepository.ListMissionRequests", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "userMissionRepository.ListMissionRequests", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
 
-	if err != nil {
-		return nil, db.EmptyOrError(err, "missionRepository.GetNewMissionRequests, unable to get list")
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	addCondition := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, value := range values {
+			args = append(args, value)
+			placeholders[i] = len(args)
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
 	}
 
-	userMissions := make([]entities.CCUserMission, 0)
-	userMission := entities.CCUserMission{}
-
-	for rows.Next() {
-		err = rows.StructScan(&userMission)
-
-		if err != nil {
-			return nil, errors.Wrap(err, "missionRepository.GetNewMissionRequests, unable to scan misison to struct")
-		}
+	if filter.Status != "" {
+		addCondition(`us."status" = $%d`, filter.Status)
+	}
+	if filter.MissionID != 0 {
+		addCondition(`us."missionId" = $%d`, filter.MissionID)
+	}
+	if filter.UserID != 0 {
+		addCondition(`us."userId" = $%d`, filter.UserID)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		addCondition(`us."createdAt" >= $%d`, filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		addCondition(`us."createdAt" < $%d`, filter.CreatedTo)
+	}
 
-		userMissions = append(userMissions, userMission)
+	order := "DESC"
+	keysetOperator := "<"
+	if filter.Sort == contributor.SortCreatedAtAsc {
+		order = "ASC"
+		keysetOperator = ">"
 	}
 
-	return userMissions, nil
-}
+	if filter.After != nil {
+		addCondition(`(us."createdAt", us.id) `+keysetOperator+` ($%d, $%d)`, filter.After.CreatedAt, filter.After.ID)
+	}
 
-func (repo *userMissionRepository) GetNewMissionRequestsList() ([]contributor.UserMissionRequest, error) {
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
 
-	rows, err := repo.db.Queryx(
-		`SELECT
-			us.ID, us."createdAt", us."missionParameters",
-  			u.firstname, u.lastname,
-			m.title AS "Mission"
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`SELECT
+			us.ID, us."createdAt", us."userId", us."missionId", us."status", us."missionParameters",
+			us."rejectionReason", us."moderatorComment",
+			u.firstname, u.lastname, u.email,
+			m.title AS "Mission", m."parameterSchema"
 		FROM
    			"ccUserMissions" us
    		JOIN
    			"users" u ON us."userId" = u.id
    		JOIN
    			"ccMissions" m ON us."missionId" = m.id
-		WHERE
-			us."status" = 'new'`)
+		%s
+		ORDER BY
+			us."createdAt" %s, us.id %s
+		LIMIT $%d`, where, order, order, len(args))
+
+	rows, err := repo.db.QueryxContext(ctx, query, args...)
 
 	if err != nil {
-		return nil, db.EmptyOrError(err, "missionRepository.GetNewMissionRequests, unable to get list")
+		return nil, db.EmptyOrError(err, "missionRepository.ListMissionRequests, unable to get list")
 	}
+	defer rows.Close()
 
 	userMissions := make([]contributor.UserMissionRequest, 0)
-	var firstName string
-	var lastName string
-	var missionParameters *string
 
 	for rows.Next() {
 		userMission := contributor.UserMissionRequest{}
+		var missionParameters *string
+		var parameterSchema contributor.ParameterSchema
 		err = rows.Scan(
 			&userMission.ID,
 			&userMission.CreatedAt,
+			&userMission.UserID,
+			&userMission.MissionID,
+			&userMission.Status,
 			&missionParameters,
-			&firstName,
-			&lastName,
+			&userMission.RejectionReason,
+			&userMission.ModeratorComment,
+			&userMission.User.FirstName,
+			&userMission.User.LastName,
+			&userMission.User.Email,
 			&userMission.Mission,
+			&parameterSchema,
 		)
 
 		if err != nil {
-			return nil, errors.Wrap(err, "missionRepository.GetNewMissionRequests, unable to scan misison to struct")
+			return nil, errors.Wrap(err, "missionRepository.ListMissionRequests, unable to scan misison to struct")
 		}
 
-		json.Unmarshal([]byte(*missionParameters), &userMission.MissionParameters)
+		//broken parameters of one request are flagged in it and do not fail the list
+		userMission.DecodeParameters(missionParameters)
+		userMission.Parameters = parameterSchema.Describe(userMission.MissionParameters)
+		userMission.User.ID = userMission.UserID
 
-		//todo сделать обработку, если нет firstName, либо нет lastName, либо ни того ни другого (взять email)
-		userMission.UserName = firstName + " " + lastName
 		userMissions = append(userMissions, userMission)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "missionRepository.ListMissionRequests, unable to iterate rows")
+	}
+
+	return userMissions, nil
+}
+
+func (repo *userMissionRepository) SetMissionRequestStatus(ctx context.Context, update contributor.StatusUpdate, status entities.UserMissionStatus, change contributor.StatusChange) (err error) {
+	defer metrics.ObserveDBQuery("userMissionRepository.SetMissionRequestStatus", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "userMissionRepository.SetMissionRequestStatus", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "userMissionRepository.SetMissionRequestStatus, unable to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err = setStatus(ctx, tx, update, status, change); err != nil {
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(), "userMissionRepository.SetMissionRequestStatus, unable to commit")
+}
+
+func (repo *userMissionRepository) SetMissionRequestStatuses(ctx context.Context, updates []contributor.StatusUpdate, status entities.UserMissionStatus, change contributor.StatusChange) (err error) {
+	defer metrics.ObserveDBQuery("userMissionRepository.SetMissionRequestStatuses", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "userMissionRepository.SetMissionRequestStatuses", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "userMissionRepository.SetMissionRequestStatuses, unable to begin transaction")
+	}
+	defer tx.Rollback()
+
+	for _, update := range updates {
+		err = setStatus(ctx, tx, update, status, change)
+		if err == contributor.ErrStatusConflict {
+			return &contributor.RequestConflictError{ID: update.ID}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrap(tx.Commit(), "userMissionRepository.SetMissionRequestStatuses, unable to commit")
+}
+
+func (repo *userMissionRepository) GetByIDs(ctx context.Context, ids []int64) (_ []contributor.UserMission, err error) {
+	defer metrics.ObserveDBQuery("userMissionRepository.GetByIDs", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "userMissionRepository.GetByIDs", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	userMissions := make([]contributor.UserMission, 0)
+	if len(ids) == 0 {
+		return userMissions, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT * FROM "ccUserMissions" WHERE "id" IN (?)`, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "userMissionRepository.GetByIDs, unable to build query")
+	}
+
+	err = sqlx.SelectContext(ctx, repo.db, &userMissions, repo.db.Rebind(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "userMissionRepository.GetByIDs, unable to get missions")
+	}
+
 	return userMissions, nil
 }
 
-func (repo *userMissionRepository) SetMissionRequestStatus(id int64, status entities.UserMissionStatus) error {
-	_, err := repo.db.Exec(`
+// setStatus moves request from expected status to status and writes status history within tx,
+// zero actor is stored as NULL meaning change made by the contributor.
+// Approved request with positive reward gets a pending entry in payout ledger,
+// event of the update goes to notification outbox.
+func setStatus(ctx context.Context, tx *sqlx.Tx, update contributor.StatusUpdate, status entities.UserMissionStatus, change contributor.StatusChange) error {
+	res, err := tx.ExecContext(ctx, `
 		UPDATE
 			"ccUserMissions"
 		SET
-			status=$2
+			status=$3,
+			"rejectionReason"=$4,
+			"moderatorComment"=COALESCE(NULLIF($5, ''), "moderatorComment")
+		WHERE
+			id=$1 AND status=$2
+	`, update.ID, update.Expected, status, change.Reason, change.Comment)
+	if err != nil {
+		return errors.Wrap(err, "userMissionRepository.setStatus, unable to update status")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "userMissionRepository.setStatus, unable to get affected rows")
+	}
+
+	if affected == 0 {
+		return contributor.ErrStatusConflict
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO
+			"ccUserMissionStatusHistory" ("userMissionId", "actorId", "oldStatus", "newStatus", "comment")
+		VALUES
+			($1, NULLIF($2, 0), $3, $4, $5)
+	`, update.ID, change.ActorID, update.Expected, status, change.Comment)
+	if err != nil {
+		return errors.Wrap(err, "userMissionRepository.setStatus, unable to write status history")
+	}
+
+	if update.Event != nil {
+		if err = notificationPostgres.WriteOutbox(ctx, tx, *update.Event); err != nil {
+			return errors.Wrap(err, "userMissionRepository.setStatus, unable to write notification")
+		}
+	}
+
+	//missions without reward have nothing to pay
+	if status != contributor.StatusApproved || !update.Reward.IsPositive() {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO
+			"ccPayouts" ("userMissionId", "userId", "missionId", "amount")
+		SELECT
+			id, "userId", "missionId", $2
+		FROM
+			"ccUserMissions"
 		WHERE
 			id=$1
-	`, id, status)
+	`, update.ID, update.Reward)
+	if err != nil {
+		return errors.Wrap(err, "userMissionRepository.setStatus, unable to credit payout")
+	}
+
+	return nil
+}
+
+func (repo *userMissionRepository) GetStatusHistory(ctx context.Context, id int64) (_ []contributor.StatusHistoryEntry, err error) {
+	defer metrics.ObserveDBQuery("userMissionRepository.GetStatusHistory", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "userMissionRepository.GetStatusHistory", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	history := make([]contributor.StatusHistoryEntry, 0)
+	err = sqlx.SelectContext(ctx, repo.db, &history, `
+		SELECT
+			h.id, h."userMissionId", COALESCE(h."actorId", 0) AS "actorId", COALESCE(u.email, '') AS "actorEmail",
+			h."oldStatus", h."newStatus", h.comment, h."createdAt"
+		FROM
+			"ccUserMissionStatusHistory" h
+		LEFT JOIN
+			"ccAdmins" a ON h."actorId" = a.id
+		LEFT JOIN
+			"users" u ON a."userId" = u.id
+		WHERE
+			h."userMissionId" = $1
+		ORDER BY
+			h."createdAt", h.id`, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "userMissionRepository.GetStatusHistory, unable to get history")
+	}
+
+	return history, nil
+}
+
+func (repo *userMissionRepository) CreateSubmission(ctx context.Context, request *contributor.UserMission, limit contributor.SubmissionLimit) (_ int64, err error) {
+	defer metrics.ObserveDBQuery("userMissionRepository.CreateSubmission", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "userMissionRepository.CreateSubmission", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "userMissionRepository.CreateSubmission, unable to begin transaction")
+	}
+	defer tx.Rollback()
+
+	//serializes submissions of the user until commit, so checks below see each other's rows.
+	//ids beyond int4 fold into shared keys, which only serializes more
+	_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, ($2::bigint % 2147483647)::int)`,
+		submissionLockNamespace, request.UserId)
+	if err != nil {
+		return 0, errors.Wrap(err, "userMissionRepository.CreateSubmission, unable to lock user submissions")
+	}
+
+	var submitted int
+	err = tx.GetContext(ctx, &submitted, `
+		SELECT
+			COUNT(*)
+		FROM
+			"ccUserMissions"
+		WHERE
+			"userId"=$1 AND "createdAt" > $2`,
+		request.UserId, request.CreatedAt.Add(-limit.Window))
+	if err != nil {
+		return 0, errors.Wrap(err, "userMissionRepository.CreateSubmission, unable to count submissions")
+	}
+
+	if submitted >= limit.Count {
+		return 0, &contributor.RateLimitError{Limit: limit}
+	}
+
+	//parameters are compared decoded, so stored rows of other formatting or broken ones do not fail the check
+	submittedParameters := make([]*string, 0)
+	err = sqlx.SelectContext(ctx, tx, &submittedParameters, `
+		SELECT
+			"missionParameters"
+		FROM
+			"ccUserMissions"
+		WHERE
+			"userId"=$1 AND "missionId"=$2 AND status NOT IN ($3, $4)`,
+		request.UserId, request.MissionId, contributor.StatusRejected, contributor.StatusWithdrawn)
+	if err != nil {
+		return 0, errors.Wrap(err, "userMissionRepository.CreateSubmission, unable to check duplicates")
+	}
+
+	params, err := contributor.DecodeMissionParameters(request.MissionParameters)
+	if err != nil {
+		return 0, errors.Wrap(err, "userMissionRepository.CreateSubmission, unable to decode parameters")
+	}
+
+	for _, raw := range submittedParameters {
+		submitted, err := contributor.DecodeMissionParameters(raw)
+		if err != nil {
+			metrics.ParametersDecodeErrors.WithLabelValues("userMissionRepository.CreateSubmission").Inc()
+			continue
+		}
+		if reflect.DeepEqual(params, submitted) {
+			return 0, contributor.ErrDuplicateSubmission
+		}
+	}
+
+	var id int64
+	err = tx.GetContext(ctx, &id, `
+		INSERT INTO
+			"ccUserMissions" ("createdAt", "userId", "missionId", "status", "missionParameters")
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING
+			id`,
+		request.CreatedAt, request.UserId, request.MissionId, request.Status, request.MissionParameters)
+	if err != nil {
+		return 0, errors.Wrap(err, "userMissionRepository.CreateSubmission, unable to insert request")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "userMissionRepository.CreateSubmission, unable to commit")
+	}
 
-	return err
+	return id, nil
 }
 
 func NewUserMissionRepository(db *sqlx.DB) (contributor.UserMissionRepository, error) {
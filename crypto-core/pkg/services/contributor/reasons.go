@@ -0,0 +1,55 @@
+package contributor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrRejectionReasonRequired   = errors.New("rejection reason is required")
+	ErrRejectionReasonNotAllowed = errors.New("rejection reason is allowed only for rejected requests")
+)
+
+// RejectionReason is an entry of configurable rejection reasons catalogue
+type RejectionReason struct {
+	Code  string `json:"code"`
+	Title string `json:"title"`
+}
+
+type UnknownRejectionReasonError struct {
+	Code string
+}
+
+func (e *UnknownRejectionReasonError) Error() string {
+	return fmt.Sprintf("unknown rejection reason %q", e.Code)
+}
+
+// ParseRejectionReasons parses catalogue in "code=Title;code=Title" format
+func ParseRejectionReasons(value string) ([]RejectionReason, error) {
+	reasons := make([]RejectionReason, 0)
+	seen := make(map[string]bool)
+
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, errors.Errorf("contributor.ParseRejectionReasons, wrong catalogue item %q", item)
+		}
+
+		code := strings.TrimSpace(parts[0])
+		if seen[code] {
+			return nil, errors.Errorf("contributor.ParseRejectionReasons, duplicate code %q", code)
+		}
+		seen[code] = true
+
+		reasons = append(reasons, RejectionReason{Code: code, Title: strings.TrimSpace(parts[1])})
+	}
+
+	return reasons, nil
+}
@@ -0,0 +1,49 @@
+package contributor
+
+import (
+	"context"
+
+	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/bfg-dev/crypto-core/pkg/helpers/currency"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ErrPaidThroughPayouts is returned on attempt to mark request paid bypassing payout ledger
+var ErrPaidThroughPayouts = errors.New("mission request becomes paid only when its payout batch is paid")
+
+// atxPerToken is the number of ATx units in one token, taken from currency.DenormalizeATx.
+// The probe is large enough for the result to be exact however DenormalizeATx rounds
+var atxPerToken = func() decimal.Decimal {
+	probe := decimal.New(1, 36)
+	return probe.Div(currency.DenormalizeATx(probe))
+}()
+
+// NormalizeReward converts mission reward in tokens into ledger ATx units,
+// it is the inverse of currency.DenormalizeATx, fractions smaller than one unit are dropped.
+// Multiplication keeps the value exact, division would be rounded to decimal.DivisionPrecision
+func NormalizeReward(reward decimal.Decimal) decimal.Decimal {
+	return reward.Mul(atxPerToken).Truncate(0)
+}
+
+// statusUpdate builds update of request, approved request is credited with its mission reward.
+// rewards caches normalized reward by mission id.
+func (s *service) statusUpdate(ctx context.Context, request *UserMission, status entities.UserMissionStatus, rewards map[int64]decimal.Decimal) (StatusUpdate, error) {
+	update := StatusUpdate{ID: request.ID, Expected: request.Status}
+	if status != StatusApproved {
+		return update, nil
+	}
+
+	reward, ok := rewards[request.MissionId]
+	if !ok {
+		mission, err := s.GetMission(ctx, request.MissionId)
+		if err != nil {
+			return update, err
+		}
+		reward = NormalizeReward(mission.Reward)
+		rewards[request.MissionId] = reward
+	}
+
+	update.Reward = reward
+	return update, nil
+}
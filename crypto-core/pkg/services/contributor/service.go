@@ -1,44 +1,169 @@
 package contributor
 
 import (
+	"context"
+
 	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/bfg-dev/crypto-core/pkg/services/notification"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 )
 
 type service struct {
 	db              *sqlx.DB
 	missionRepo     MissionRepository
 	userMissionRepo UserMissionRepository
+	reasons         []RejectionReason
+	submissionLimit SubmissionLimit
+	namePolicy      DisplayNamePolicy
+	statusListeners []func()
 }
 
-func (s *service) GetNewMissionRequests() ([]entities.CCUserMission, error) {
-	requests, err := s.userMissionRepo.GetNewMissionRequests()
-	if err != nil {
-		return nil, errors.Wrap(err, "contributor.GetNewMissionRequests, unable to get new requests")
+func (s *service) OnStatusChanged(listener func()) {
+	s.statusListeners = append(s.statusListeners, listener)
+}
+
+func (s *service) statusChanged() {
+	for _, listener := range s.statusListeners {
+		listener()
 	}
-	return requests, nil
 }
 
-func (s *service) GetNewMissionRequestsList() ([]UserMissionRequest, error) {
-	requests, err := s.userMissionRepo.GetNewMissionRequestsList()
+func (s *service) ListMissionRequests(ctx context.Context, filter ListFilter) (*ListPage, error) {
+	if err := filter.Normalize(); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	//one extra row tells whether there is a next page
+	filter.Limit++
+
+	requests, err := s.userMissionRepo.ListMissionRequests(ctx, filter)
 	if err != nil {
-		return nil, errors.Wrap(err, "contributor.GetNewMissionRequests, unable to get new requests")
+		return nil, errors.Wrap(err, "contributor.ListMissionRequests, unable to get requests")
 	}
-	return requests, nil
+
+	page := &ListPage{Requests: requests}
+	if len(requests) > limit {
+		page.Requests = requests[:limit]
+		last := page.Requests[limit-1]
+		page.NextCursor = ListCursor{CreatedAt: last.CreatedAt, ID: last.ID}.String()
+	}
+
+	for i := range page.Requests {
+		page.Requests[i].UserName = s.namePolicy.DisplayName(page.Requests[i].User, filter.MaskEmails)
+		if reason, ok := s.rejectionReason(page.Requests[i].RejectionReason); ok {
+			page.Requests[i].RejectionReasonTitle = reason.Title
+		}
+	}
+	return page, nil
 }
 
-func (s *service) SetMissionRequestStatus(id int64, status entities.UserMissionStatus) error {
-	err := s.userMissionRepo.SetMissionRequestStatus(id, status)
+func (s *service) SetMissionRequestStatus(ctx context.Context, id int64, status entities.UserMissionStatus, change StatusChange) error {
+	if change.ActorID == 0 {
+		return errors.New("contributor.SetMissionRequestStatus, actor cannot be empty")
+	}
+
+	request, err := s.userMissionRepo.GetByID(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "contributor.SetMissionRequestStatus, unable to get request")
+	}
+
+	if request == nil {
+		return ErrUserMissionNotFound
+	}
+
+	if err = ValidateStatusTransition(request.Status, status); err != nil {
+		return err
+	}
+
+	if status == StatusPaid {
+		return ErrPaidThroughPayouts
+	}
+
+	if status == StatusWithdrawn {
+		return ErrWithdrawnByContributor
+	}
+
+	if err = s.validateRejectionReason(status, change.Reason); err != nil {
+		return err
+	}
+
+	update, err := s.statusUpdate(ctx, request, status, make(map[int64]decimal.Decimal))
+	if err != nil {
+		return errors.Wrap(err, "contributor.SetMissionRequestStatus, unable to get mission reward")
+	}
+	update.Event = s.statusChangedEvent(ctx, update, status, change)
+
+	err = s.userMissionRepo.SetMissionRequestStatus(ctx, update, status, change)
+	if err == ErrStatusConflict {
+		return err
+	}
 	if err != nil {
 		return errors.Wrap(err, "contributor.SetMissionRequestStatus, unable to set request status")
 	}
+
+	s.statusChanged()
+	return nil
+}
+
+func (s *service) GetMissionRequestStatusHistory(ctx context.Context, id int64) ([]StatusHistoryEntry, error) {
+	history, err := s.userMissionRepo.GetStatusHistory(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.GetMissionRequestStatusHistory, unable to get status history")
+	}
+	return history, nil
+}
+
+// statusChangedEvent builds event notifying contributor about status change of update
+func (s *service) statusChangedEvent(ctx context.Context, update StatusUpdate, status entities.UserMissionStatus, change StatusChange) *notification.Event {
+	event := notification.NewStatusChangedEvent(ctx, update.ID, change.ActorID, update.Expected, status)
+	event.Reason = change.Reason
+	event.Comment = change.Comment
+	if reason, ok := s.rejectionReason(change.Reason); ok {
+		event.ReasonTitle = reason.Title
+	}
+	return &event
+}
+
+func (s *service) RejectionReasons() []RejectionReason {
+	return s.reasons
+}
+
+func (s *service) rejectionReason(code string) (RejectionReason, bool) {
+	for _, reason := range s.reasons {
+		if reason.Code == code {
+			return reason, true
+		}
+	}
+	return RejectionReason{}, false
+}
+
+func (s *service) validateRejectionReason(status entities.UserMissionStatus, code string) error {
+	if status != StatusRejected {
+		if code != "" {
+			return ErrRejectionReasonNotAllowed
+		}
+		return nil
+	}
+
+	if code == "" {
+		return ErrRejectionReasonRequired
+	}
+
+	if _, ok := s.rejectionReason(code); !ok {
+		return &UnknownRejectionReasonError{Code: code}
+	}
 	return nil
 }
 
 func NewService(
 	missionRepo MissionRepository,
 	userMissionRepo UserMissionRepository,
+	reasons []RejectionReason,
+	submissionLimit SubmissionLimit,
+	namePolicy DisplayNamePolicy,
 	) (Service, error) {
 
 	if missionRepo == nil {
@@ -49,8 +174,23 @@ func NewService(
 		return nil, errors.New("contributor.NewService, userMissionRepo cannot be empty")
 	}
 
+	if len(reasons) == 0 {
+		return nil, errors.New("contributor.NewService, reasons cannot be empty")
+	}
+
+	if submissionLimit.Count <= 0 || submissionLimit.Window <= 0 {
+		return nil, errors.New("contributor.NewService, submissionLimit should be positive")
+	}
+
+	if _, err := ParseDisplayNamePolicy(string(namePolicy)); err != nil || namePolicy == "" {
+		return nil, errors.New("contributor.NewService, namePolicy is wrong")
+	}
+
 	return &service{
 		missionRepo:     missionRepo,
 		userMissionRepo: userMissionRepo,
+		reasons:         reasons,
+		submissionLimit: submissionLimit,
+		namePolicy:      namePolicy,
 	}, nil
 }
@@ -0,0 +1,68 @@
+package contributor
+
+import (
+	"fmt"
+
+	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/pkg/errors"
+)
+
+const (
+	StatusNew      entities.UserMissionStatus = "new"
+	StatusApproved entities.UserMissionStatus = "approved"
+	StatusRejected entities.UserMissionStatus = "rejected"
+	StatusPaid     entities.UserMissionStatus = "paid"
+	// StatusWithdrawn is set by contributor who cancels own request before moderation
+	StatusWithdrawn entities.UserMissionStatus = "withdrawn"
+)
+
+// ErrStatusConflict is returned when request status was changed by someone else
+// between reading and updating it
+var ErrStatusConflict = errors.New("mission request status was changed concurrently")
+
+// statusTransitions lists statuses reachable from every known status.
+// Statuses without outgoing transitions are final.
+var statusTransitions = map[entities.UserMissionStatus][]entities.UserMissionStatus{
+	StatusNew:       {StatusApproved, StatusRejected, StatusWithdrawn},
+	StatusApproved:  {StatusPaid},
+	StatusRejected:  {},
+	StatusPaid:      {},
+	StatusWithdrawn: {},
+}
+
+type UnknownStatusError struct {
+	Status entities.UserMissionStatus
+}
+
+func (e *UnknownStatusError) Error() string {
+	return fmt.Sprintf("unknown mission request status %q", e.Status)
+}
+
+type InvalidTransitionError struct {
+	From entities.UserMissionStatus
+	To   entities.UserMissionStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("mission request status cannot be changed from %q to %q", e.From, e.To)
+}
+
+// ValidateStatusTransition checks that request in status from can be moved to status to
+func ValidateStatusTransition(from, to entities.UserMissionStatus) error {
+	allowed, ok := statusTransitions[from]
+	if !ok {
+		return &UnknownStatusError{Status: from}
+	}
+
+	if _, ok := statusTransitions[to]; !ok {
+		return &UnknownStatusError{Status: to}
+	}
+
+	for _, status := range allowed {
+		if status == to {
+			return nil
+		}
+	}
+
+	return &InvalidTransitionError{From: from, To: to}
+}
@@ -0,0 +1,150 @@
+package contributor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/pkg/errors"
+)
+
+func TestValidateStatusTransition(t *testing.T) {
+	const unknown entities.UserMissionStatus = "archived"
+
+	tests := []struct {
+		from, to      entities.UserMissionStatus
+		wantInvalid   bool
+		wantUnknown   bool
+		unknownStatus entities.UserMissionStatus
+	}{
+		{from: StatusNew, to: StatusApproved},
+		{from: StatusNew, to: StatusRejected},
+		{from: StatusNew, to: StatusWithdrawn},
+		{from: StatusApproved, to: StatusPaid},
+
+		{from: StatusNew, to: StatusNew, wantInvalid: true},
+		{from: StatusNew, to: StatusPaid, wantInvalid: true},
+		{from: StatusApproved, to: StatusRejected, wantInvalid: true},
+		{from: StatusApproved, to: StatusNew, wantInvalid: true},
+		{from: StatusRejected, to: StatusApproved, wantInvalid: true},
+		{from: StatusRejected, to: StatusNew, wantInvalid: true},
+		{from: StatusPaid, to: StatusApproved, wantInvalid: true},
+		{from: StatusWithdrawn, to: StatusNew, wantInvalid: true},
+
+		{from: unknown, to: StatusApproved, wantUnknown: true, unknownStatus: unknown},
+		{from: StatusNew, to: unknown, wantUnknown: true, unknownStatus: unknown},
+		{from: "", to: StatusNew, wantUnknown: true, unknownStatus: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			err := ValidateStatusTransition(tt.from, tt.to)
+
+			var transitionErr *InvalidTransitionError
+			var unknownErr *UnknownStatusError
+			switch {
+			case tt.wantInvalid:
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("got %v, want InvalidTransitionError", err)
+				}
+				if transitionErr.From != tt.from || transitionErr.To != tt.to {
+					t.Errorf("got transition %q -> %q, want %q -> %q", transitionErr.From, transitionErr.To, tt.from, tt.to)
+				}
+			case tt.wantUnknown:
+				if !errors.As(err, &unknownErr) {
+					t.Fatalf("got %v, want UnknownStatusError", err)
+				}
+				if unknownErr.Status != tt.unknownStatus {
+					t.Errorf("got unknown status %q, want %q", unknownErr.Status, tt.unknownStatus)
+				}
+			case err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// statusRepository serves one request and reports setErr on status update, other methods are not used
+type statusRepository struct {
+	UserMissionRepository
+	request *UserMission
+	setErr  error
+
+	updates []StatusUpdate
+}
+
+func (r *statusRepository) GetByID(ctx context.Context, id int64) (*UserMission, error) {
+	if r.request == nil || r.request.ID != id {
+		return nil, nil
+	}
+	return r.request, nil
+}
+
+func (r *statusRepository) SetMissionRequestStatus(ctx context.Context, update StatusUpdate, status entities.UserMissionStatus, change StatusChange) error {
+	r.updates = append(r.updates, update)
+	return r.setErr
+}
+
+func TestSetMissionRequestStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     entities.UserMissionStatus
+		status      entities.UserMissionStatus
+		reason      string
+		setErr      error
+		wantErr     error
+		wantInvalid bool
+		wantUpdate  bool
+	}{
+		{name: "reject", current: StatusNew, status: StatusRejected, reason: "other", wantUpdate: true},
+		{name: "changed concurrently", current: StatusNew, status: StatusRejected, reason: "other", setErr: ErrStatusConflict, wantErr: ErrStatusConflict, wantUpdate: true},
+		{name: "final status", current: StatusRejected, status: StatusNew, wantInvalid: true},
+		{name: "reject approved", current: StatusApproved, status: StatusRejected, reason: "other", wantInvalid: true},
+		{name: "paid through payouts", current: StatusApproved, status: StatusPaid, wantErr: ErrPaidThroughPayouts},
+		{name: "withdrawn by contributor", current: StatusNew, status: StatusWithdrawn, wantErr: ErrWithdrawnByContributor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &statusRepository{setErr: tt.setErr}
+			repo.request = &UserMission{}
+			repo.request.ID = 1
+			repo.request.Status = tt.current
+
+			s, err := NewService(struct{ MissionRepository }{}, repo,
+				[]RejectionReason{{Code: "other", Title: "Другое"}},
+				SubmissionLimit{Count: 1, Window: time.Hour},
+				NamePolicyFullName)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = s.SetMissionRequestStatus(context.Background(), 1, tt.status, StatusChange{ActorID: 2, Reason: tt.reason})
+
+			var transitionErr *InvalidTransitionError
+			switch {
+			case tt.wantInvalid:
+				if !errors.As(err, &transitionErr) {
+					t.Fatalf("got %v, want InvalidTransitionError", err)
+				}
+			case err != tt.wantErr:
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+
+			if !tt.wantUpdate {
+				if len(repo.updates) != 0 {
+					t.Fatalf("got %d updates, want none", len(repo.updates))
+				}
+				return
+			}
+
+			if len(repo.updates) != 1 {
+				t.Fatalf("got %d updates, want 1", len(repo.updates))
+			}
+			if repo.updates[0].Expected != tt.current {
+				t.Errorf("got expected status %q, want %q", repo.updates[0].Expected, tt.current)
+			}
+		})
+	}
+}
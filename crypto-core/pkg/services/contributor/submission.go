@@ -0,0 +1,200 @@
+package contributor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrMissionNotActive       = errors.New("mission is not open for submissions")
+	ErrDuplicateSubmission    = errors.New("the same request for this mission was already submitted")
+	ErrWithdrawnByContributor = errors.New("mission request can be withdrawn only by its contributor")
+)
+
+// SubmissionLimit allows Count submissions of one contributor within sliding Window
+type SubmissionLimit struct {
+	Count  int
+	Window time.Duration
+}
+
+type RateLimitError struct {
+	Limit SubmissionLimit
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("no more than %d requests can be submitted within %s", e.Limit.Count, e.Limit.Window)
+}
+
+func (s *service) ListActiveMissions(ctx context.Context) ([]Mission, error) {
+	missions, err := s.missionRepo.List(ctx, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.ListActiveMissions, unable to get missions")
+	}
+
+	now := time.Now()
+	active := make([]Mission, 0, len(missions))
+	for _, mission := range missions {
+		if mission.IsActive(now) {
+			active = append(active, mission)
+		}
+	}
+	return active, nil
+}
+
+func (s *service) Submit(ctx context.Context, userID int64, missionID int64, params map[string]string) (*UserMissionRequest, error) {
+	if userID == 0 {
+		return nil, errors.New("contributor.Submit, user cannot be empty")
+	}
+
+	mission, err := s.GetMission(ctx, missionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !mission.IsActive(now) {
+		return nil, ErrMissionNotActive
+	}
+
+	params = normalizeParameters(params)
+	if err = mission.ParameterSchema.Validate(params); err != nil {
+		return nil, err
+	}
+
+	//map keys are marshalled sorted, so equal parameters give equal json
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.Submit, unable to encode parameters")
+	}
+	missionParameters := string(encoded)
+
+	request := &UserMission{}
+	request.CreatedAt = now.UTC()
+	request.UserId = userID
+	request.MissionId = missionID
+	request.Status = StatusNew
+	request.MissionParameters = &missionParameters
+
+	request.ID, err = s.userMissionRepo.CreateSubmission(ctx, request, s.submissionLimit)
+	var limitErr *RateLimitError
+	if err == ErrDuplicateSubmission || errors.As(err, &limitErr) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.Submit, unable to create request")
+	}
+
+	return s.submissionRequest(request, mission), nil
+}
+
+func (s *service) ListSubmissions(ctx context.Context, userID int64, filter ListFilter) (*ListPage, error) {
+	if userID == 0 {
+		return nil, errors.New("contributor.ListSubmissions, user cannot be empty")
+	}
+
+	filter.UserID = userID
+	filter.MaskEmails = false
+	page, err := s.ListMissionRequests(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range page.Requests {
+		page.Requests[i].RawParameters = ""
+	}
+	return page, nil
+}
+
+func (s *service) GetSubmission(ctx context.Context, userID int64, id int64) (*UserMissionRequest, error) {
+	request, err := s.ownRequest(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	mission, err := s.GetMission(ctx, request.MissionId)
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.GetSubmission, unable to get mission")
+	}
+
+	return s.submissionRequest(request, mission), nil
+}
+
+func (s *service) WithdrawSubmission(ctx context.Context, userID int64, id int64) error {
+	request, err := s.ownRequest(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err = ValidateStatusTransition(request.Status, StatusWithdrawn); err != nil {
+		return err
+	}
+
+	//zero actor marks change made by the contributor
+	err = s.userMissionRepo.SetMissionRequestStatus(ctx, StatusUpdate{ID: request.ID, Expected: request.Status}, StatusWithdrawn, StatusChange{})
+	if err == ErrStatusConflict {
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "contributor.WithdrawSubmission, unable to set request status")
+	}
+
+	s.statusChanged()
+	return nil
+}
+
+// ownRequest returns request of the user, requests of other users look like missing ones
+func (s *service) ownRequest(ctx context.Context, userID int64, id int64) (*UserMission, error) {
+	if userID == 0 {
+		return nil, errors.New("contributor.ownRequest, user cannot be empty")
+	}
+
+	request, err := s.userMissionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "contributor.ownRequest, unable to get request")
+	}
+
+	if request == nil || request.UserId != userID {
+		return nil, ErrUserMissionNotFound
+	}
+	return request, nil
+}
+
+func (s *service) submissionRequest(request *UserMission, mission *Mission) *UserMissionRequest {
+	rs := &UserMissionRequest{
+		ID:               request.ID,
+		CreatedAt:        request.CreatedAt,
+		UserID:           request.UserId,
+		MissionID:        request.MissionId,
+		Mission:          mission.Title,
+		Status:           request.Status,
+		RejectionReason:  request.RejectionReason,
+		ModeratorComment: request.ModeratorComment,
+	}
+
+	rs.DecodeParameters(request.MissionParameters)
+	//stored value is shown to admins only, contributor sees that parameters are broken
+	rs.RawParameters = ""
+	rs.Parameters = mission.ParameterSchema.Describe(rs.MissionParameters)
+
+	if reason, ok := s.rejectionReason(request.RejectionReason); ok {
+		rs.RejectionReasonTitle = reason.Title
+	}
+	return rs
+}
+
+// normalizeParameters trims values and drops empty ones, so optional parameters left blank
+// do not make otherwise equal submissions different
+func normalizeParameters(params map[string]string) map[string]string {
+	normalized := make(map[string]string, len(params))
+	for name, value := range params {
+		if value = strings.TrimSpace(value); value != "" {
+			normalized[name] = value
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,32 @@
+package contributorauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired contributor token")
+
+// Service checks bearer tokens of contributors. Tokens are issued by the platform users log in to,
+// both sides share the signing secret.
+type Service interface {
+	// Issue returns token of the user valid for ttl
+	Issue(userID int64, ttl time.Duration) (string, error)
+	// Authenticate returns id of the user the token was issued to
+	Authenticate(token string) (int64, error)
+}
+
+type contextKey struct{}
+
+// WithUserID returns ctx carrying the authenticated contributor
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, contextKey{}, userID)
+}
+
+// UserIDFromContext returns the contributor put into ctx by the auth middleware
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(contextKey{}).(int64)
+	return userID, ok && userID != 0
+}
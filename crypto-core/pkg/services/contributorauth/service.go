@@ -0,0 +1,73 @@
+package contributorauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// minSecretBytes keeps tokens from being signed with a guessable secret
+const minSecretBytes = 32
+
+// service works with "<userId>.<expiresAt unix>.<hex hmac-sha256 of the first two parts>" tokens
+type service struct {
+	secret []byte
+}
+
+func (s *service) Issue(userID int64, ttl time.Duration) (string, error) {
+	if userID <= 0 {
+		return "", errors.New("contributorauth.Issue, user cannot be empty")
+	}
+
+	if ttl <= 0 {
+		return "", errors.New("contributorauth.Issue, ttl should be positive")
+	}
+
+	payload := strconv.FormatInt(userID, 10) + "." + strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	return payload + "." + s.sign(payload), nil
+}
+
+func (s *service) Authenticate(token string) (int64, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, ErrInvalidToken
+	}
+
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(payload))) {
+		return 0, ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, ErrInvalidToken
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() >= expiresAt {
+		return 0, ErrInvalidToken
+	}
+
+	return userID, nil
+}
+
+func (s *service) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func NewService(secret string) (Service, error) {
+	if len(secret) < minSecretBytes {
+		return nil, errors.Errorf("contributorauth.NewService, secret should be at least %d bytes", minSecretBytes)
+	}
+
+	return &service{
+		secret: []byte(secret),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package cryptofund
+
+import (
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
+	"github.com/pkg/errors"
+)
+
+// metricsService observes duration and result of cryptofund api calls
+type metricsService struct {
+	next Service
+}
+
+func (s *metricsService) GetIndexes() (indexes []Index, err error) {
+	defer func(start time.Time) {
+		metrics.ObserveExternalCall("cryptofund", "GetIndexes", start, err)
+	}(time.Now())
+
+	return s.next.GetIndexes()
+}
+
+func NewMetricsService(next Service) (Service, error) {
+	if next == nil {
+		return nil, errors.New("cryptofund.NewMetricsService, next cannot be empty")
+	}
+
+	return &metricsService{next: next}, nil
+}
@@ -0,0 +1,223 @@
+package leaderboard
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/helpers/currency"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// PeriodAll is code of the period covering all time
+const PeriodAll = "all"
+
+var ErrUnknownPeriod = errors.New("unknown statistics period")
+
+type LimitError struct {
+	Max int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("limit should be between 1 and %d", e.Max)
+}
+
+// Period covers Days calendar days up to today inclusive, zero Days means all time.
+// Code is "<days>d" or "all".
+type Period struct {
+	Code string `json:"code"`
+	Days int    `json:"days"`
+}
+
+// Since returns start of the period at moment now, zero time for all time period
+func (p Period) Since(now time.Time) time.Time {
+	if p.Days == 0 {
+		return time.Time{}
+	}
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return today.AddDate(0, 0, 1-p.Days)
+}
+
+// ParsePeriods reads comma separated period codes, e.g. "7d,30d,all". The first one is the default.
+func ParsePeriods(value string) ([]Period, error) {
+	periods := make([]Period, 0)
+	seen := make(map[string]bool)
+
+	for _, code := range strings.Split(value, ",") {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+
+		period := Period{Code: code}
+		if code != PeriodAll {
+			days, err := strconv.Atoi(strings.TrimSuffix(code, "d"))
+			if err != nil || !strings.HasSuffix(code, "d") || days <= 0 {
+				return nil, errors.Errorf("leaderboard.ParsePeriods, wrong period %q", code)
+			}
+			period.Days = days
+		}
+
+		if seen[code] {
+			return nil, errors.Errorf("leaderboard.ParsePeriods, duplicate period %q", code)
+		}
+		seen[code] = true
+		periods = append(periods, period)
+	}
+
+	if len(periods) == 0 {
+		return nil, errors.New("leaderboard.ParsePeriods, periods cannot be empty")
+	}
+	return periods, nil
+}
+
+// ContributorStats aggregates mission requests of one user created within period.
+// Approved counts paid requests too, Rewards are in tokens.
+type ContributorStats struct {
+	Rank          int             `json:"rank"`
+	UserID        int64           `json:"userId"`
+	UserName      string          `json:"userName"`
+	Submitted     int             `json:"submitted"`
+	Approved      int             `json:"approved"`
+	Rejected      int             `json:"rejected"`
+	ApprovalRate  decimal.Decimal `json:"approvalRate"`
+	Rewards       decimal.Decimal `json:"rewards"`
+	CurrentStreak int             `json:"currentStreak"`
+	LongestStreak int             `json:"longestStreak"`
+	// OptedOut users are shown in admin reports only
+	OptedOut bool `json:"optedOut"`
+}
+
+// MissionStats aggregates requests of one mission created within period
+type MissionStats struct {
+	MissionID    int64           `json:"missionId"`
+	Mission      string          `json:"mission"`
+	Contributors int             `json:"contributors"`
+	Submitted    int             `json:"submitted"`
+	Approved     int             `json:"approved"`
+	Rejected     int             `json:"rejected"`
+	ApprovalRate decimal.Decimal `json:"approvalRate"`
+	Rewards      decimal.Decimal `json:"rewards"`
+}
+
+type Leaderboard struct {
+	Period       Period             `json:"period"`
+	GeneratedAt  time.Time          `json:"generatedAt"`
+	Contributors []ContributorStats `json:"contributors"`
+}
+
+// PublicContributorStats is ContributorStats without user identifiers
+type PublicContributorStats struct {
+	Rank          int             `json:"rank"`
+	UserName      string          `json:"userName"`
+	Submitted     int             `json:"submitted"`
+	Approved      int             `json:"approved"`
+	Rejected      int             `json:"rejected"`
+	ApprovalRate  decimal.Decimal `json:"approvalRate"`
+	Rewards       decimal.Decimal `json:"rewards"`
+	CurrentStreak int             `json:"currentStreak"`
+	LongestStreak int             `json:"longestStreak"`
+}
+
+// PublicLeaderboard is shown to contributors
+type PublicLeaderboard struct {
+	Period       Period                   `json:"period"`
+	GeneratedAt  time.Time                `json:"generatedAt"`
+	Contributors []PublicContributorStats `json:"contributors"`
+}
+
+// Public leaves out user identifiers of the board
+func (b *Leaderboard) Public() *PublicLeaderboard {
+	public := &PublicLeaderboard{
+		Period:       b.Period,
+		GeneratedAt:  b.GeneratedAt,
+		Contributors: make([]PublicContributorStats, len(b.Contributors)),
+	}
+	for i, c := range b.Contributors {
+		public.Contributors[i] = PublicContributorStats{
+			Rank:          c.Rank,
+			UserName:      c.UserName,
+			Submitted:     c.Submitted,
+			Approved:      c.Approved,
+			Rejected:      c.Rejected,
+			ApprovalRate:  c.ApprovalRate,
+			Rewards:       c.Rewards,
+			CurrentStreak: c.CurrentStreak,
+			LongestStreak: c.LongestStreak,
+		}
+	}
+	return public
+}
+
+type MissionReport struct {
+	Period      Period         `json:"period"`
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Missions    []MissionStats `json:"missions"`
+}
+
+// ContributorTotals are raw per-user counters read from storage, rewards are in ATx units
+type ContributorTotals struct {
+	contributor.UserIdentity
+	Submitted int             `db:"submitted"`
+	Approved  int             `db:"approved"`
+	Rejected  int             `db:"rejected"`
+	Rewards   decimal.Decimal `db:"rewards"`
+	OptedOut  bool            `db:"optedOut"`
+}
+
+type MissionTotals struct {
+	MissionID    int64           `db:"missionId"`
+	Mission      string          `db:"mission"`
+	Contributors int             `db:"contributors"`
+	Submitted    int             `db:"submitted"`
+	Approved     int             `db:"approved"`
+	Rejected     int             `db:"rejected"`
+	Rewards      decimal.Decimal `db:"rewards"`
+}
+
+type Repository interface {
+	// ContributorTotals returns up to limit users ordered by approved requests and rewards.
+	// Zero since means all time.
+	ContributorTotals(ctx context.Context, since time.Time, includeOptedOut bool, limit int) ([]ContributorTotals, error)
+	// ApprovedDays returns ascending UTC days on which requests of every user were approved since the moment,
+	// requests which are not approved or paid now are left out
+	ApprovedDays(ctx context.Context, since time.Time, userIDs []int64) (map[int64][]time.Time, error)
+	MissionTotals(ctx context.Context, since time.Time) ([]MissionTotals, error)
+	SetOptOut(ctx context.Context, userID int64, optOut bool) error
+	IsOptedOut(ctx context.Context, userID int64) (bool, error)
+}
+
+type Service interface {
+	Periods() []Period
+	// Leaderboard ranks contributors, public one leaves out opted out users. Empty period means default one.
+	// Limit out of range returns *LimitError
+	Leaderboard(ctx context.Context, period string, public bool, limit int) (*Leaderboard, error)
+	MissionReport(ctx context.Context, period string) (*MissionReport, error)
+	// SetOptOut hides user from public leaderboard or shows the user again
+	SetOptOut(ctx context.Context, userID int64, optOut bool) error
+	IsOptedOut(ctx context.Context, userID int64) (bool, error)
+	// Invalidate drops every cached report, so changed statuses are shown at once
+	Invalidate()
+}
+
+// approvalRate is share of approved requests among moderated ones
+func approvalRate(approved, rejected int) decimal.Decimal {
+	if approved+rejected == 0 {
+		return decimal.Zero
+	}
+	return decimal.New(int64(approved), 0).Div(decimal.New(int64(approved+rejected), 0)).Round(4)
+}
+
+func rewardTokens(amount decimal.Decimal) decimal.Decimal {
+	return currency.DenormalizeATx(amount)
+}
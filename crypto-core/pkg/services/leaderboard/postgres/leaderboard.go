@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/bfg-dev/crypto-core/pkg/services/leaderboard"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type leaderboardRepository struct {
+	db *sqlx.DB
+}
+
+func (repo *leaderboardRepository) ContributorTotals(ctx context.Context, since time.Time, includeOptedOut bool, limit int) (_ []leaderboard.ContributorTotals, err error) {
+	defer metrics.ObserveDBQuery("leaderboardRepository.ContributorTotals", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "leaderboardRepository.ContributorTotals", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	totals := make([]leaderboard.ContributorTotals, 0)
+	err = sqlx.SelectContext(ctx, repo.db, &totals, `
+		SELECT
+			u.id, u.firstname, u.lastname, u.email,
+			COUNT(*) AS submitted,
+			COUNT(*) FILTER (WHERE us.status IN ($2, $3)) AS approved,
+			COUNT(*) FILTER (WHERE us.status = $4) AS rejected,
+			COALESCE(SUM(p.amount), 0) AS rewards,
+			o."userId" IS NOT NULL AS "optedOut"
+		FROM
+			"ccUserMissions" us
+		JOIN
+			"users" u ON us."userId" = u.id
+		LEFT JOIN
+			"ccPayouts" p ON p."userMissionId" = us.id
+		LEFT JOIN
+			"ccLeaderboardOptOuts" o ON o."userId" = us."userId"
+		WHERE
+			us."createdAt" >= $1 AND ($5 OR o."userId" IS NULL)
+		GROUP BY
+			u.id, o."userId"
+		HAVING
+			COUNT(*) FILTER (WHERE us.status IN ($2, $3)) > 0
+		ORDER BY
+			approved DESC, rewards DESC, u.id
+		LIMIT $6`,
+		since, contributor.StatusApproved, contributor.StatusPaid, contributor.StatusRejected, includeOptedOut, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "leaderboardRepository.ContributorTotals, unable to get totals")
+	}
+
+	return totals, nil
+}
+
+func (repo *leaderboardRepository) ApprovedDays(ctx context.Context, since time.Time, userIDs []int64) (_ map[int64][]time.Time, err error) {
+	defer metrics.ObserveDBQuery("leaderboardRepository.ApprovedDays", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "leaderboardRepository.ApprovedDays", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	days := make(map[int64][]time.Time, len(userIDs))
+	if len(userIDs) == 0 {
+		return days, nil
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT DISTINCT
+			us."userId", date_trunc('day', h."createdAt") AS day
+		FROM
+			"ccUserMissionStatusHistory" h
+		JOIN
+			"ccUserMissions" us ON h."userMissionId" = us.id
+		WHERE
+			us."userId" IN (?) AND h."newStatus" = ? AND h."createdAt" >= ? AND us.status IN (?, ?)
+		ORDER BY
+			us."userId", day`,
+		userIDs, contributor.StatusApproved, since, contributor.StatusApproved, contributor.StatusPaid)
+	if err != nil {
+		return nil, errors.Wrap(err, "leaderboardRepository.ApprovedDays, unable to build query")
+	}
+
+	rows, err := repo.db.QueryxContext(ctx, repo.db.Rebind(query), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "leaderboardRepository.ApprovedDays, unable to get days")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int64
+		var day time.Time
+		if err = rows.Scan(&userID, &day); err != nil {
+			return nil, errors.Wrap(err, "leaderboardRepository.ApprovedDays, unable to scan day")
+		}
+		day = day.UTC()
+		days[userID] = append(days[userID], time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "leaderboardRepository.ApprovedDays, unable to iterate rows")
+	}
+
+	return days, nil
+}
+
+func (repo *leaderboardRepository) MissionTotals(ctx context.Context, since time.Time) (_ []leaderboard.MissionTotals, err error) {
+	defer metrics.ObserveDBQuery("leaderboardRepository.MissionTotals", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "leaderboardRepository.MissionTotals", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	totals := make([]leaderboard.MissionTotals, 0)
+	err = sqlx.SelectContext(ctx, repo.db, &totals, `
+		SELECT
+			us."missionId",
+			m.title AS mission,
+			COUNT(DISTINCT us."userId") AS contributors,
+			COUNT(*) AS submitted,
+			COUNT(*) FILTER (WHERE us.status IN ($2, $3)) AS approved,
+			COUNT(*) FILTER (WHERE us.status = $4) AS rejected,
+			COALESCE(SUM(p.amount), 0) AS rewards
+		FROM
+			"ccUserMissions" us
+		JOIN
+			"ccMissions" m ON us."missionId" = m.id
+		LEFT JOIN
+			"ccPayouts" p ON p."userMissionId" = us.id
+		WHERE
+			us."createdAt" >= $1
+		GROUP BY
+			us."missionId", m.title
+		ORDER BY
+			approved DESC, submitted DESC, us."missionId"`,
+		since, contributor.StatusApproved, contributor.StatusPaid, contributor.StatusRejected)
+	if err != nil {
+		return nil, errors.Wrap(err, "leaderboardRepository.MissionTotals, unable to get totals")
+	}
+
+	return totals, nil
+}
+
+func (repo *leaderboardRepository) SetOptOut(ctx context.Context, userID int64, optOut bool) (err error) {
+	defer metrics.ObserveDBQuery("leaderboardRepository.SetOptOut", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "leaderboardRepository.SetOptOut", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	if optOut {
+		_, err = repo.db.ExecContext(ctx, `
+			INSERT INTO
+				"ccLeaderboardOptOuts" ("userId")
+			VALUES
+				($1)
+			ON CONFLICT DO NOTHING`, userID)
+	} else {
+		_, err = repo.db.ExecContext(ctx, `DELETE FROM "ccLeaderboardOptOuts" WHERE "userId"=$1`, userID)
+	}
+	if err != nil {
+		return errors.Wrap(err, "leaderboardRepository.SetOptOut, unable to save opt-out")
+	}
+
+	return nil
+}
+
+func (repo *leaderboardRepository) IsOptedOut(ctx context.Context, userID int64) (_ bool, err error) {
+	defer metrics.ObserveDBQuery("leaderboardRepository.IsOptedOut", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "leaderboardRepository.IsOptedOut", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	var optedOut bool
+	err = repo.db.GetContext(ctx, &optedOut, `SELECT EXISTS (SELECT 1 FROM "ccLeaderboardOptOuts" WHERE "userId"=$1)`, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "leaderboardRepository.IsOptedOut, unable to get opt-out")
+	}
+
+	return optedOut, nil
+}
+
+func NewLeaderboardRepository(db *sqlx.DB) (leaderboard.Repository, error) {
+	if db == nil {
+		return nil, errors.New("NewLeaderboardRepository: db connection is empty")
+	}
+
+	return &leaderboardRepository{db}, nil
+}
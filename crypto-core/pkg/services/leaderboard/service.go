@@ -0,0 +1,298 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/services/cache"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type service struct {
+	logger   *zap.Logger
+	repo     Repository
+	cache    cache.Cache
+	cacheTTL time.Duration
+	periods  []Period
+	// namePolicy shows contributors in boards
+	namePolicy contributor.DisplayNamePolicy
+}
+
+func (s *service) Periods() []Period {
+	return s.periods
+}
+
+func (s *service) Leaderboard(ctx context.Context, code string, public bool, limit int) (*Leaderboard, error) {
+	period, err := s.period(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 0 || limit > MaxLimit {
+		return nil, &LimitError{Max: MaxLimit}
+	}
+
+	//full board is cached once per period, so opt-out drops every cached limit
+	board := &Leaderboard{}
+	err = s.cached(leaderboardCacheKey(period, public), board, func() error {
+		return s.buildLeaderboard(ctx, board, period, public, MaxLimit)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(board.Contributors) > limit {
+		board.Contributors = board.Contributors[:limit]
+	}
+	return board, nil
+}
+
+func (s *service) buildLeaderboard(ctx context.Context, board *Leaderboard, period Period, public bool, limit int) error {
+	now := time.Now().UTC()
+	since := period.Since(now)
+
+	totals, err := s.repo.ContributorTotals(ctx, since, !public, limit)
+	if err != nil {
+		return errors.Wrap(err, "leaderboard.Leaderboard, unable to get contributor totals")
+	}
+
+	userIDs := make([]int64, len(totals))
+	for i, total := range totals {
+		userIDs[i] = total.ID
+	}
+
+	days, err := s.repo.ApprovedDays(ctx, since, userIDs)
+	if err != nil {
+		return errors.Wrap(err, "leaderboard.Leaderboard, unable to get approved days")
+	}
+
+	board.Period = period
+	board.GeneratedAt = now
+	board.Contributors = make([]ContributorStats, len(totals))
+	for i, total := range totals {
+		current, longest := streaks(days[total.ID], now)
+		board.Contributors[i] = ContributorStats{
+			Rank:   i + 1,
+			UserID: total.ID,
+			//boards are cached for every reader, so e-mail is never shown in full
+			UserName:      s.namePolicy.DisplayName(total.UserIdentity, true),
+			Submitted:     total.Submitted,
+			Approved:      total.Approved,
+			Rejected:      total.Rejected,
+			ApprovalRate:  approvalRate(total.Approved, total.Rejected),
+			Rewards:       rewardTokens(total.Rewards),
+			CurrentStreak: current,
+			LongestStreak: longest,
+			OptedOut:      total.OptedOut,
+		}
+	}
+	return nil
+}
+
+func (s *service) MissionReport(ctx context.Context, code string) (*MissionReport, error) {
+	period, err := s.period(code)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &MissionReport{}
+	err = s.cached(missionReportCacheKey(period), report, func() error {
+		now := time.Now().UTC()
+		totals, err := s.repo.MissionTotals(ctx, period.Since(now))
+		if err != nil {
+			return errors.Wrap(err, "leaderboard.MissionReport, unable to get mission totals")
+		}
+
+		report.Period = period
+		report.GeneratedAt = now
+		report.Missions = make([]MissionStats, len(totals))
+		for i, total := range totals {
+			report.Missions[i] = MissionStats{
+				MissionID:    total.MissionID,
+				Mission:      total.Mission,
+				Contributors: total.Contributors,
+				Submitted:    total.Submitted,
+				Approved:     total.Approved,
+				Rejected:     total.Rejected,
+				ApprovalRate: approvalRate(total.Approved, total.Rejected),
+				Rewards:      rewardTokens(total.Rewards),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *service) SetOptOut(ctx context.Context, userID int64, optOut bool) error {
+	if userID == 0 {
+		return errors.New("leaderboard.SetOptOut, user cannot be empty")
+	}
+
+	if err := s.repo.SetOptOut(ctx, userID, optOut); err != nil {
+		return errors.Wrap(err, "leaderboard.SetOptOut, unable to save opt-out")
+	}
+
+	//public boards are dropped at once, so the user does not wait for cache expiration
+	keys := make([]string, 0, len(s.periods))
+	for _, period := range s.periods {
+		keys = append(keys, leaderboardCacheKey(period, true))
+	}
+	s.drop(keys)
+	return nil
+}
+
+func (s *service) Invalidate() {
+	keys := make([]string, 0, 3*len(s.periods))
+	for _, period := range s.periods {
+		keys = append(keys, leaderboardCacheKey(period, true), leaderboardCacheKey(period, false), missionReportCacheKey(period))
+	}
+	s.drop(keys)
+}
+
+// drop removes cached reports, failure is logged and reports expire by TTL
+func (s *service) drop(keys []string) {
+	if err := s.cache.Delete(keys...); err != nil {
+		s.logger.Error("unable to drop cached reports", zap.Strings("keys", keys), zap.Error(err))
+	}
+}
+
+func (s *service) IsOptedOut(ctx context.Context, userID int64) (bool, error) {
+	optedOut, err := s.repo.IsOptedOut(ctx, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "leaderboard.IsOptedOut, unable to get opt-out")
+	}
+	return optedOut, nil
+}
+
+func (s *service) period(code string) (Period, error) {
+	if code == "" {
+		return s.periods[0], nil
+	}
+
+	for _, period := range s.periods {
+		if period.Code == code {
+			return period, nil
+		}
+	}
+	return Period{}, ErrUnknownPeriod
+}
+
+// cached decodes report stored by key into dst, on cache miss build fills dst and the result is cached.
+// Cache failures are logged and do not fail the report
+func (s *service) cached(key string, dst interface{}, build func() error) error {
+	encoded, ok, err := s.cache.Get(key)
+	if err != nil {
+		s.logger.Error("unable to get report from cache", zap.String("key", key), zap.Error(err))
+	}
+
+	if ok {
+		if err = json.Unmarshal(encoded, dst); err == nil {
+			return nil
+		}
+		s.logger.Error("unable to decode cached report", zap.String("key", key), zap.Error(err))
+	}
+
+	if err = build(); err != nil {
+		return err
+	}
+
+	encoded, err = json.Marshal(dst)
+	if err != nil {
+		s.logger.Error("unable to encode report for cache", zap.String("key", key), zap.Error(err))
+		return nil
+	}
+
+	if err = s.cache.Set(key, encoded, s.cacheTTL); err != nil {
+		s.logger.Error("unable to put report to cache", zap.String("key", key), zap.Error(err))
+	}
+	return nil
+}
+
+func leaderboardCacheKey(period Period, public bool) string {
+	return fmt.Sprintf("leaderboard:%t:%s", public, period.Code)
+}
+
+func missionReportCacheKey(period Period) string {
+	return fmt.Sprintf("leaderboard.missions:%s", period.Code)
+}
+
+// streaks returns number of consecutive days ending today or yesterday and the longest run of
+// consecutive days, days are ascending UTC midnights
+func streaks(days []time.Time, now time.Time) (int, int) {
+	longest, run := 0, 0
+	for i, day := range days {
+		if i > 0 && day.Equal(days[i-1].AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	if len(days) == 0 {
+		return 0, 0
+	}
+
+	//streak is not broken until the day passes without approved requests
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	last := days[len(days)-1]
+	if last.Equal(today) || last.Equal(today.AddDate(0, 0, -1)) {
+		return run, longest
+	}
+	return 0, longest
+}
+
+func NewService(
+	logger *zap.Logger,
+	repo Repository,
+	reportCache cache.Cache,
+	cacheTTL time.Duration,
+	periods []Period,
+	namePolicy contributor.DisplayNamePolicy,
+) (Service, error) {
+
+	if logger == nil {
+		return nil, errors.New("leaderboard.NewService, logger cannot be empty")
+	}
+
+	if repo == nil {
+		return nil, errors.New("leaderboard.NewService, repo cannot be empty")
+	}
+
+	if reportCache == nil {
+		return nil, errors.New("leaderboard.NewService, reportCache cannot be empty")
+	}
+
+	if cacheTTL <= 0 {
+		return nil, errors.New("leaderboard.NewService, cacheTTL must be positive")
+	}
+
+	if len(periods) == 0 {
+		return nil, errors.New("leaderboard.NewService, periods cannot be empty")
+	}
+
+	if namePolicy == "" {
+		return nil, errors.New("leaderboard.NewService, namePolicy cannot be empty")
+	}
+
+	return &service{
+		logger:     logger,
+		repo:       repo,
+		cache:      reportCache,
+		cacheTTL:   cacheTTL,
+		periods:    periods,
+		namePolicy: namePolicy,
+	}, nil
+}
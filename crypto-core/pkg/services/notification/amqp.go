@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+
+	"github.com/pkg/errors"
+)
+
+// AMQPPublisher is the part of amqp connection provider used to publish notifications
+type AMQPPublisher interface {
+	Publish(ctx context.Context, exchange, routingKey string, body []byte, headers map[string]interface{}) error
+}
+
+type amqpDeliverer struct {
+	publisher AMQPPublisher
+	exchange  string
+}
+
+func (d *amqpDeliverer) Channel() string {
+	return "amqp"
+}
+
+// Deliver publishes message with "contributor.<event type>.<new status>" routing key
+func (d *amqpDeliverer) Deliver(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return &PermanentError{Err: errors.Wrap(err, "amqpDeliverer.Deliver, unable to marshal message")}
+	}
+
+	//consumers continue the trace of the status change
+	headers := make(map[string]interface{})
+	tracing.InjectHeaders(ctx, headers)
+
+	routingKey := "contributor." + string(msg.Event.Type) + "." + string(msg.Event.NewStatus)
+	if err = d.publisher.Publish(ctx, d.exchange, routingKey, body, headers); err != nil {
+		return errors.Wrap(err, "amqpDeliverer.Deliver, unable to publish")
+	}
+	return nil
+}
+
+func NewAMQPDeliverer(publisher AMQPPublisher, exchange string) (Deliverer, error) {
+	if publisher == nil {
+		return nil, errors.New("notification.NewAMQPDeliverer, publisher cannot be empty")
+	}
+
+	if exchange == "" {
+		return nil, errors.New("notification.NewAMQPDeliverer, exchange cannot be empty")
+	}
+
+	return &amqpDeliverer{
+		publisher: publisher,
+		exchange:  exchange,
+	}, nil
+}
@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+)
+
+type EventType string
+
+const EventStatusChanged EventType = "mission_request.status_changed"
+
+// Event is a domain event written to the outbox in the transaction of mission request status change
+type Event struct {
+	ID            string                     `json:"id"`
+	Type          EventType                  `json:"type"`
+	UserMissionID int64                      `json:"userMissionId"`
+	ActorID       int64                      `json:"actorId"`
+	OldStatus     entities.UserMissionStatus `json:"oldStatus"`
+	NewStatus     entities.UserMissionStatus `json:"newStatus"`
+	Reason        string                     `json:"reason,omitempty"`
+	ReasonTitle   string                     `json:"reasonTitle,omitempty"`
+	Comment       string                     `json:"comment,omitempty"`
+	OccurredAt    time.Time                  `json:"occurredAt"`
+	// Trace is trace context of the status change, deliveries continue it
+	Trace map[string]string `json:"trace,omitempty"`
+}
+
+// Recipient is the contributor who sent the mission request
+type Recipient struct {
+	UserID  int64  `db:"userId" json:"userId"`
+	Email   string `db:"email" json:"email"`
+	Name    string `db:"name" json:"name"`
+	Mission string `db:"mission" json:"mission"`
+}
+
+// Message is an event rendered for its recipient
+type Message struct {
+	Event     Event     `json:"event"`
+	Recipient Recipient `json:"recipient"`
+	Subject   string    `json:"subject"`
+	Text      string    `json:"text"`
+}
+
+// DeadLetter is a message which could not be delivered through the channel
+type DeadLetter struct {
+	ID        int64     `db:"id"`
+	EventID   string    `db:"eventId"`
+	Channel   string    `db:"channel"`
+	Payload   string    `db:"payload"`
+	Error     string    `db:"error"`
+	Attempts  int       `db:"attempts"`
+	CreatedAt time.Time `db:"createdAt"`
+}
+
+// OutboxEntry is an event waiting for delivery. Done lists channels which need no more attempts,
+// Attempts counts attempts made so far
+type OutboxEntry struct {
+	ID       int64
+	Payload  string
+	Done     []string
+	Attempts int
+}
+
+// Deliverer sends message through one channel
+type Deliverer interface {
+	Channel() string
+	Deliver(ctx context.Context, msg Message) error
+}
+
+type RecipientRepository interface {
+	// GetRecipient returns nil if mission request does not exist
+	GetRecipient(ctx context.Context, userMissionID int64) (*Recipient, error)
+}
+
+type OutboxRepository interface {
+	// Claim returns up to limit entries due for delivery and postpones them by lease,
+	// so other dispatchers skip entries while they are delivered
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]OutboxEntry, error)
+	// Reschedule stores channels done so far and postpones the next attempt by delay
+	Reschedule(ctx context.Context, id int64, done []string, attempts int, delay time.Duration) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type DeadLetterRepository interface {
+	Create(ctx context.Context, letter *DeadLetter) error
+}
+
+// PermanentError marks delivery error which will not go away on retry
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// NewStatusChangedEvent builds event with unique id, deliverers pass the id on so consumers can drop duplicates.
+// Trace context of ctx is kept in the event, deliveries continue the trace of the status change
+func NewStatusChangedEvent(ctx context.Context, userMissionID, actorID int64, oldStatus, newStatus entities.UserMissionStatus) Event {
+	return Event{
+		ID:            newEventID(),
+		Type:          EventStatusChanged,
+		UserMissionID: userMissionID,
+		ActorID:       actorID,
+		OldStatus:     oldStatus,
+		NewStatus:     newStatus,
+		OccurredAt:    time.Now().UTC(),
+		Trace:         tracing.TraceContext(ctx),
+	}
+}
+
+func newEventID() string {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return time.Now().UTC().Format("20060102150405.000000000")
+	}
+	return hex.EncodeToString(raw)
+}
@@ -0,0 +1,342 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// dispatcherChannel names dead letters of events which failed before reaching any deliverer
+const dispatcherChannel = "dispatcher"
+
+type DispatcherConfig struct {
+	// PollInterval is the pause between outbox polls when no more entries are due
+	PollInterval time.Duration
+	// BatchSize limits entries claimed by one poll
+	BatchSize      int
+	Workers        int
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+	// AttemptTimeout limits one delivery attempt
+	AttemptTimeout time.Duration
+}
+
+// Dispatcher delivers events of the outbox through every deliverer. Failed channels are retried
+// with exponential backoff by the next attempt time stored in the outbox, messages failed after
+// MaxAttempts go to dead letters. Entries are removed once every channel is done.
+type Dispatcher struct {
+	logger      *zap.Logger
+	outbox      OutboxRepository
+	recipients  RecipientRepository
+	deadLetters DeadLetterRepository
+	templates   *Templates
+	deliverers  []Deliverer
+	config      DispatcherConfig
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Close stops polling and waits for claimed entries until ctx is done,
+// entries left undelivered are claimed again after their lease
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+	})
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "notification.Close, claimed events are not delivered")
+	}
+}
+
+func (d *Dispatcher) run() {
+	defer close(d.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-timer.C:
+		}
+
+		delay := d.config.PollInterval
+		//full batch means more entries may be due
+		if d.poll() == d.config.BatchSize {
+			delay = 0
+		}
+		timer.Reset(delay)
+	}
+}
+
+// poll claims due entries and handles them by Workers at once, returns the number of claimed entries
+func (d *Dispatcher) poll() int {
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.AttemptTimeout)
+	entries, err := d.outbox.Claim(ctx, d.config.BatchSize, d.lease())
+	cancel()
+	if err != nil {
+		d.logger.Error("unable to claim notification outbox entries", zap.Error(err))
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, d.config.Workers)
+	for i := range entries {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(entry OutboxEntry) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			d.handle(entry)
+		}(entries[i])
+	}
+	wg.Wait()
+
+	return len(entries)
+}
+
+// lease covers handling of the whole claimed batch: recipient lookup and every delivery
+// of each entry, entries are handled by Workers at once
+func (d *Dispatcher) lease() time.Duration {
+	rounds := (d.config.BatchSize + d.config.Workers - 1) / d.config.Workers
+	return d.config.AttemptTimeout * time.Duration((len(d.deliverers)+1)*rounds)
+}
+
+func (d *Dispatcher) handle(entry OutboxEntry) {
+	attempt := entry.Attempts + 1
+
+	var event Event
+	if err := json.Unmarshal([]byte(entry.Payload), &event); err != nil {
+		d.saveDeadLetter(&DeadLetter{
+			Channel:  dispatcherChannel,
+			Payload:  entry.Payload,
+			Error:    errors.Wrap(err, "unable to decode event").Error(),
+			Attempts: attempt,
+		})
+		d.remove(entry)
+		return
+	}
+
+	pending := d.pending(entry.Done)
+	if len(pending) == 0 {
+		d.remove(entry)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.AttemptTimeout)
+	recipient, err := d.recipients.GetRecipient(ctx, event.UserMissionID)
+	cancel()
+	if err != nil {
+		err = errors.Wrap(err, "unable to get recipient")
+		if attempt >= d.config.MaxAttempts {
+			d.deadLetter(Message{Event: event}, dispatcherChannel, err, attempt)
+			d.remove(entry)
+			return
+		}
+		d.logRetry(dispatcherChannel, event.ID, attempt, err)
+		d.retry(entry, entry.Done, attempt)
+		return
+	}
+
+	if recipient == nil {
+		d.logger.Warn("notification recipient not found", zap.String("eventID", event.ID), zap.Int64("userMissionID", event.UserMissionID))
+		d.remove(entry)
+		return
+	}
+
+	msg, err := d.templates.Render(event, *recipient)
+	if err != nil {
+		d.deadLetter(msg, dispatcherChannel, err, attempt)
+		d.remove(entry)
+		return
+	}
+
+	done := entry.Done
+	retry := false
+	for _, deliverer := range pending {
+		err = d.deliver(deliverer, msg)
+		if err == nil {
+			done = append(done, deliverer.Channel())
+			continue
+		}
+
+		var permanent *PermanentError
+		if errors.As(err, &permanent) || attempt >= d.config.MaxAttempts {
+			d.deadLetter(msg, deliverer.Channel(), err, attempt)
+			done = append(done, deliverer.Channel())
+			continue
+		}
+
+		d.logRetry(deliverer.Channel(), event.ID, attempt, err)
+		retry = true
+	}
+
+	if !retry {
+		d.remove(entry)
+		return
+	}
+
+	d.retry(entry, done, attempt)
+}
+
+// deliver makes one delivery attempt, retries are scheduled by handle
+func (d *Dispatcher) deliver(deliverer Deliverer, msg Message) error {
+	ctx, cancel := context.WithTimeout(tracing.WithTraceContext(context.Background(), msg.Event.Trace), d.config.AttemptTimeout)
+	defer cancel()
+
+	ctx, span := tracing.StartSpan(ctx, "notification.Deliver", attribute.String("channel", deliverer.Channel()))
+	err := deliverer.Deliver(ctx, msg)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// pending returns deliverers of channels not done yet
+func (d *Dispatcher) pending(done []string) []Deliverer {
+	pending := make([]Deliverer, 0, len(d.deliverers))
+	for _, deliverer := range d.deliverers {
+		if !containsChannel(done, deliverer.Channel()) {
+			pending = append(pending, deliverer)
+		}
+	}
+	return pending
+}
+
+func containsChannel(channels []string, channel string) bool {
+	for _, c := range channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) logRetry(channel string, eventID string, attempt int, cause error) {
+	d.logger.Warn("notification delivery failed, retrying",
+		zap.String("channel", channel), zap.String("eventID", eventID),
+		zap.Int("attempt", attempt), zap.Duration("delay", d.backoff(attempt)), zap.Error(cause))
+}
+
+// retry schedules the next attempt of channels not done after backoff
+func (d *Dispatcher) retry(entry OutboxEntry, done []string, attempt int) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.AttemptTimeout)
+	defer cancel()
+
+	if err := d.outbox.Reschedule(ctx, entry.ID, done, attempt, d.backoff(attempt)); err != nil {
+		d.logger.Error("unable to reschedule notification", zap.Int64("outboxID", entry.ID), zap.Error(err))
+	}
+}
+
+func (d *Dispatcher) remove(entry OutboxEntry) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.AttemptTimeout)
+	defer cancel()
+
+	if err := d.outbox.Delete(ctx, entry.ID); err != nil {
+		d.logger.Error("unable to delete notification outbox entry", zap.Int64("outboxID", entry.ID), zap.Error(err))
+	}
+}
+
+// backoff doubles delay after every attempt up to RetryMaxDelay
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.config.RetryBaseDelay
+	for i := 1; i < attempt && delay < d.config.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > d.config.RetryMaxDelay {
+		delay = d.config.RetryMaxDelay
+	}
+	return delay
+}
+
+func (d *Dispatcher) deadLetter(msg Message, channel string, cause error, attempts int) {
+	d.logger.Error("notification is not delivered",
+		zap.String("channel", channel), zap.String("eventID", msg.Event.ID),
+		zap.Int("attempts", attempts), zap.Error(cause))
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		d.logger.Error("unable to marshal dead letter", zap.String("eventID", msg.Event.ID), zap.Error(err))
+		return
+	}
+
+	d.saveDeadLetter(&DeadLetter{
+		EventID:  msg.Event.ID,
+		Channel:  channel,
+		Payload:  string(payload),
+		Error:    cause.Error(),
+		Attempts: attempts,
+	})
+}
+
+func (d *Dispatcher) saveDeadLetter(letter *DeadLetter) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.AttemptTimeout)
+	defer cancel()
+
+	if err := d.deadLetters.Create(ctx, letter); err != nil {
+		d.logger.Error("unable to save dead letter", zap.String("eventID", letter.EventID), zap.Error(err))
+	}
+}
+
+func NewDispatcher(
+	logger *zap.Logger,
+	outbox OutboxRepository,
+	recipients RecipientRepository,
+	deadLetters DeadLetterRepository,
+	templates *Templates,
+	deliverers []Deliverer,
+	config DispatcherConfig,
+) (*Dispatcher, error) {
+
+	if logger == nil {
+		return nil, errors.New("notification.NewDispatcher, logger cannot be empty")
+	}
+
+	if outbox == nil {
+		return nil, errors.New("notification.NewDispatcher, outbox cannot be empty")
+	}
+
+	if recipients == nil {
+		return nil, errors.New("notification.NewDispatcher, recipients cannot be empty")
+	}
+
+	if deadLetters == nil {
+		return nil, errors.New("notification.NewDispatcher, deadLetters cannot be empty")
+	}
+
+	if templates == nil {
+		return nil, errors.New("notification.NewDispatcher, templates cannot be empty")
+	}
+
+	if config.PollInterval <= 0 || config.BatchSize <= 0 || config.Workers <= 0 || config.MaxAttempts <= 0 ||
+		config.RetryBaseDelay <= 0 || config.RetryMaxDelay < config.RetryBaseDelay || config.AttemptTimeout <= 0 {
+		return nil, errors.New("notification.NewDispatcher, wrong config")
+	}
+
+	d := &Dispatcher{
+		logger:      logger,
+		outbox:      outbox,
+		recipients:  recipients,
+		deadLetters: deadLetters,
+		templates:   templates,
+		deliverers:  deliverers,
+		config:      config,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	go d.run()
+
+	return d, nil
+}
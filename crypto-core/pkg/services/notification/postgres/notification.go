@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/helpers/db"
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
+	"github.com/bfg-dev/crypto-core/pkg/services/notification"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type recipientRepository struct {
+	db sqlx.ExtContext
+}
+
+func (repo *recipientRepository) GetRecipient(ctx context.Context, userMissionID int64) (_ *notification.Recipient, err error) {
+	defer metrics.ObserveDBQuery("recipientRepository.GetRecipient", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "recipientRepository.GetRecipient", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	rs := notification.Recipient{}
+	err = repo.db.QueryRowxContext(ctx, `
+		SELECT
+			u.id AS "userId",
+			COALESCE(u.email, '') AS email,
+			TRIM(COALESCE(u.firstname, '') || ' ' || COALESCE(u.lastname, '')) AS name,
+			m.title AS mission
+		FROM
+			"ccUserMissions" us
+		JOIN
+			"users" u ON us."userId" = u.id
+		JOIN
+			"ccMissions" m ON us."missionId" = m.id
+		WHERE
+			us.id = $1`, userMissionID).StructScan(&rs)
+	if err != nil {
+		return nil, db.EmptyOrError(err, "recipientRepository.GetRecipient, unable to get recipient")
+	}
+
+	return &rs, nil
+}
+
+type deadLetterRepository struct {
+	db sqlx.ExtContext
+}
+
+func (repo *deadLetterRepository) Create(ctx context.Context, letter *notification.DeadLetter) (err error) {
+	defer metrics.ObserveDBQuery("deadLetterRepository.Create", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "deadLetterRepository.Create", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	_, err = repo.db.ExecContext(ctx, `
+		INSERT INTO
+			"ccNotificationDeadLetters" ("eventId", channel, payload, error, attempts)
+		VALUES
+			($1, $2, $3, $4, $5)
+	`, letter.EventID, letter.Channel, letter.Payload, letter.Error, letter.Attempts)
+	if err != nil {
+		return errors.Wrap(err, "deadLetterRepository.Create, unable to insert dead letter")
+	}
+
+	return nil
+}
+
+func NewRecipientRepository(db *sqlx.DB) (notification.RecipientRepository, error) {
+	if db == nil {
+		return nil, errors.New("NewRecipientRepository: db connection is empty")
+	}
+
+	return &recipientRepository{db}, nil
+}
+
+func NewDeadLetterRepository(db *sqlx.DB) (notification.DeadLetterRepository, error) {
+	if db == nil {
+		return nil, errors.New("NewDeadLetterRepository: db connection is empty")
+	}
+
+	return &deadLetterRepository{db}, nil
+}
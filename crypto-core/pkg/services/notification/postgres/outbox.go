@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
+	"github.com/bfg-dev/crypto-core/pkg/services/notification"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WriteOutbox writes events to the notification outbox within tx of the change they describe,
+// so events are delivered if and only if the change is committed
+func WriteOutbox(ctx context.Context, tx sqlx.ExecerContext, events ...notification.Event) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return errors.Wrap(err, "notification.WriteOutbox, unable to marshal event")
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO
+				"ccNotificationOutbox" ("eventId", payload)
+			VALUES
+				($1, $2)
+		`, event.ID, string(payload))
+		if err != nil {
+			return errors.Wrap(err, "notification.WriteOutbox, unable to insert event")
+		}
+	}
+	return nil
+}
+
+type outboxEntry struct {
+	ID       int64  `db:"id"`
+	Payload  string `db:"payload"`
+	Done     string `db:"done"`
+	Attempts int    `db:"attempts"`
+}
+
+type outboxRepository struct {
+	db sqlx.ExtContext
+}
+
+func (repo *outboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) (_ []notification.OutboxEntry, err error) {
+	defer metrics.ObserveDBQuery("outboxRepository.Claim", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "outboxRepository.Claim", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	rows := make([]outboxEntry, 0)
+	err = sqlx.SelectContext(ctx, repo.db, &rows, `
+		UPDATE
+			"ccNotificationOutbox" o
+		SET
+			"nextAttemptAt" = now() + $2 * interval '1 millisecond'
+		FROM (
+			SELECT
+				id
+			FROM
+				"ccNotificationOutbox"
+			WHERE
+				"nextAttemptAt" <= now()
+			ORDER BY
+				"nextAttemptAt", id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		) due
+		WHERE
+			o.id = due.id
+		RETURNING
+			o.id, o.payload, o.done, o.attempts`, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, errors.Wrap(err, "outboxRepository.Claim, unable to claim entries")
+	}
+
+	entries := make([]notification.OutboxEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = notification.OutboxEntry{
+			ID:       row.ID,
+			Payload:  row.Payload,
+			Attempts: row.Attempts,
+		}
+		if row.Done != "" {
+			entries[i].Done = strings.Split(row.Done, ",")
+		}
+	}
+
+	return entries, nil
+}
+
+func (repo *outboxRepository) Reschedule(ctx context.Context, id int64, done []string, attempts int, delay time.Duration) (err error) {
+	defer metrics.ObserveDBQuery("outboxRepository.Reschedule", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "outboxRepository.Reschedule", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	_, err = repo.db.ExecContext(ctx, `
+		UPDATE
+			"ccNotificationOutbox"
+		SET
+			done=$2,
+			attempts=$3,
+			"nextAttemptAt"=now() + $4 * interval '1 millisecond'
+		WHERE
+			id=$1`, id, strings.Join(done, ","), attempts, delay.Milliseconds())
+	if err != nil {
+		return errors.Wrap(err, "outboxRepository.Reschedule, unable to update entry")
+	}
+
+	return nil
+}
+
+func (repo *outboxRepository) Delete(ctx context.Context, id int64) (err error) {
+	defer metrics.ObserveDBQuery("outboxRepository.Delete", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "outboxRepository.Delete", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	_, err = repo.db.ExecContext(ctx, `DELETE FROM "ccNotificationOutbox" WHERE id=$1`, id)
+	if err != nil {
+		return errors.Wrap(err, "outboxRepository.Delete, unable to delete entry")
+	}
+
+	return nil
+}
+
+func NewOutboxRepository(db *sqlx.DB) (notification.OutboxRepository, error) {
+	if db == nil {
+		return nil, errors.New("NewOutboxRepository: db connection is empty")
+	}
+
+	return &outboxRepository{db}, nil
+}
@@ -0,0 +1,184 @@
+// Package sink is a local stand-in for SMTP server and webhook receiver, it keeps
+// everything it receives in memory so notification delivery can be checked without
+// real mail server or webhook consumer.
+package sink
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxMessageBytes limits message accepted by SMTP stand-in
+const maxMessageBytes = 1 << 20
+
+// Mail is a message accepted by SMTP stand-in, Data holds raw headers and body
+type Mail struct {
+	From       string
+	To         []string
+	Data       string
+	ReceivedAt time.Time
+}
+
+// Request is a request accepted by webhook stand-in
+type Request struct {
+	Header     http.Header
+	Body       []byte
+	ReceivedAt time.Time
+}
+
+// Sink stores mails and webhook requests, OnMail and OnRequest are called for every received one
+type Sink struct {
+	OnMail    func(Mail)
+	OnRequest func(Request)
+	// FailRequests makes webhook answer 503 to the given number of next requests to exercise retries
+	FailRequests int
+
+	mu       sync.Mutex
+	mails    []Mail
+	requests []Request
+}
+
+func (s *Sink) Mails() []Mail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Mail(nil), s.mails...)
+}
+
+func (s *Sink) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ServeHTTP records webhook request
+func (s *Sink) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxMessageBytes))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	if s.FailRequests > 0 {
+		s.FailRequests--
+		s.mu.Unlock()
+		http.Error(w, "failing on purpose", http.StatusServiceUnavailable)
+		return
+	}
+	r := Request{Header: req.Header.Clone(), Body: body, ReceivedAt: time.Now()}
+	s.requests = append(s.requests, r)
+	s.mu.Unlock()
+
+	if s.OnRequest != nil {
+		s.OnRequest(r)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ServeSMTP accepts connections on l until it is closed. It speaks just enough SMTP
+// for net/smtp client: no TLS, no auth, any sender and recipient are accepted.
+func (s *Sink) ServeSMTP(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return errors.Wrap(err, "sink.ServeSMTP, accept failed")
+		}
+		go s.session(conn)
+	}
+}
+
+func (s *Sink) session(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(time.Minute))
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		io.WriteString(conn, line+"\r\n")
+	}
+
+	reply("220 localhost sink ready")
+	var mail Mail
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(verb, "EHLO"), strings.HasPrefix(verb, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(verb, "MAIL FROM:"):
+			mail = Mail{From: address(line[len("MAIL FROM:"):])}
+			reply("250 OK")
+		case strings.HasPrefix(verb, "RCPT TO:"):
+			mail.To = append(mail.To, address(line[len("RCPT TO:"):]))
+			reply("250 OK")
+		case verb == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			data, err := readData(r)
+			if err != nil {
+				reply("552 " + err.Error())
+				return
+			}
+			mail.Data = data
+			mail.ReceivedAt = time.Now()
+			s.mu.Lock()
+			s.mails = append(s.mails, mail)
+			s.mu.Unlock()
+			if s.OnMail != nil {
+				s.OnMail(mail)
+			}
+			reply("250 OK")
+		case verb == "RSET":
+			mail = Mail{}
+			reply("250 OK")
+		case verb == "NOOP":
+			reply("250 OK")
+		case verb == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+func readData(r *bufio.Reader) (string, error) {
+	var data strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if line == ".\r\n" || line == ".\n" {
+			return data.String(), nil
+		}
+		//dot stuffing
+		line = strings.TrimPrefix(line, ".")
+		if data.Len()+len(line) > maxMessageBytes {
+			return "", errors.New("message too big")
+		}
+		data.WriteString(line)
+	}
+}
+
+func address(value string) string {
+	value = strings.TrimSpace(value)
+	if i := strings.IndexByte(value, ' '); i >= 0 {
+		value = value[:i]
+	}
+	return strings.Trim(value, "<>")
+}
@@ -0,0 +1,138 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type smtpDeliverer struct {
+	addr string
+	host string
+	from mail.Address
+	auth smtp.Auth
+}
+
+func (d *smtpDeliverer) Channel() string {
+	return "smtp"
+}
+
+func (d *smtpDeliverer) Deliver(ctx context.Context, msg Message) error {
+	to, err := mail.ParseAddress(msg.Recipient.Email)
+	if err != nil {
+		return &PermanentError{Err: errors.Wrap(err, "smtpDeliverer.Deliver, wrong recipient e-mail")}
+	}
+
+	body, err := d.compose(to, msg)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", d.addr)
+	if err != nil {
+		return errors.Wrap(err, "smtpDeliverer.Deliver, unable to connect")
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, d.host)
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "smtpDeliverer.Deliver, unable to start session")
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: d.host}); err != nil {
+			return errors.Wrap(err, "smtpDeliverer.Deliver, unable to start tls")
+		}
+	}
+
+	if d.auth != nil {
+		if err = client.Auth(d.auth); err != nil {
+			return errors.Wrap(err, "smtpDeliverer.Deliver, unable to authenticate")
+		}
+	}
+
+	if err = client.Mail(d.from.Address); err != nil {
+		return errors.Wrap(err, "smtpDeliverer.Deliver, sender rejected")
+	}
+
+	if err = client.Rcpt(to.Address); err != nil {
+		return errors.Wrap(err, "smtpDeliverer.Deliver, recipient rejected")
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return errors.Wrap(err, "smtpDeliverer.Deliver, unable to start data")
+	}
+
+	if _, err = w.Write(body); err != nil {
+		return errors.Wrap(err, "smtpDeliverer.Deliver, unable to write message")
+	}
+
+	if err = w.Close(); err != nil {
+		return errors.Wrap(err, "smtpDeliverer.Deliver, message rejected")
+	}
+
+	return client.Quit()
+}
+
+func (d *smtpDeliverer) compose(to *mail.Address, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", d.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", msg.Event.ID, d.host)
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(msg.Text)); err != nil {
+		return nil, errors.Wrap(err, "smtpDeliverer.compose, unable to encode text")
+	}
+	if err := qp.Close(); err != nil {
+		return nil, errors.Wrap(err, "smtpDeliverer.compose, unable to encode text")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// NewSMTPDeliverer sends messages by e-mail through server at addr (host:port),
+// user and password are optional
+func NewSMTPDeliverer(addr, from, user, password string) (Deliverer, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "notification.NewSMTPDeliverer, wrong addr")
+	}
+
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, errors.Wrap(err, "notification.NewSMTPDeliverer, wrong from")
+	}
+
+	d := &smtpDeliverer{
+		addr: addr,
+		host: host,
+		from: *sender,
+	}
+
+	if user != "" {
+		d.auth = smtp.PlainAuth("", user, password, host)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// defaultTemplateKey is used for statuses without own templates
+const defaultTemplateKey = "default"
+
+var defaultTemplates = map[string][2]string{
+	"approved": {
+		`Заявка на миссию «{{.Recipient.Mission}}» одобрена`,
+		`{{.Recipient.Name}}, ваша заявка на миссию «{{.Recipient.Mission}}» одобрена, награда будет выплачена в ближайшее время.
+{{if .Event.Comment}}
+Комментарий модератора: {{.Event.Comment}}
+{{end}}`,
+	},
+	"rejected": {
+		`Заявка на миссию «{{.Recipient.Mission}}» отклонена`,
+		`{{.Recipient.Name}}, ваша заявка на миссию «{{.Recipient.Mission}}» отклонена.
+{{if .Event.ReasonTitle}}
+Причина: {{.Event.ReasonTitle}}
+{{end}}{{if .Event.Comment}}
+Комментарий модератора: {{.Event.Comment}}
+{{end}}`,
+	},
+	"paid": {
+		`Награда за миссию «{{.Recipient.Mission}}» выплачена`,
+		`{{.Recipient.Name}}, награда за миссию «{{.Recipient.Mission}}» выплачена.`,
+	},
+	defaultTemplateKey: {
+		`Статус заявки на миссию «{{.Recipient.Mission}}» изменён`,
+		`{{.Recipient.Name}}, статус вашей заявки на миссию «{{.Recipient.Mission}}» изменён на «{{.Event.NewStatus}}».`,
+	},
+}
+
+type messageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// Templates renders subject and text of messages by new request status
+type Templates struct {
+	templates map[string]messageTemplate
+}
+
+// NewTemplates parses default templates, overriding them with <status>.subject.tmpl and
+// <status>.body.tmpl files of dir if it is not empty
+func NewTemplates(dir string) (*Templates, error) {
+	sources := make(map[string][2]string, len(defaultTemplates))
+	for key, source := range defaultTemplates {
+		sources[key] = source
+	}
+
+	if dir != "" {
+		for key := range defaultTemplates {
+			for i, part := range []string{"subject", "body"} {
+				raw, err := os.ReadFile(filepath.Join(dir, key+"."+part+".tmpl"))
+				if os.IsNotExist(err) {
+					continue
+				}
+				if err != nil {
+					return nil, errors.Wrapf(err, "notification.NewTemplates, unable to read %s %s template", key, part)
+				}
+				source := sources[key]
+				source[i] = string(raw)
+				sources[key] = source
+			}
+		}
+	}
+
+	t := &Templates{templates: make(map[string]messageTemplate, len(sources))}
+	for key, source := range sources {
+		subject, err := template.New(key + ".subject").Option("missingkey=error").Parse(source[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "notification.NewTemplates, wrong %s subject template", key)
+		}
+
+		body, err := template.New(key + ".body").Option("missingkey=error").Parse(source[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "notification.NewTemplates, wrong %s body template", key)
+		}
+
+		t.templates[key] = messageTemplate{subject: subject, body: body}
+	}
+
+	return t, nil
+}
+
+func (t *Templates) Render(event Event, recipient Recipient) (Message, error) {
+	msg := Message{Event: event, Recipient: recipient}
+
+	tmpl, ok := t.templates[string(event.NewStatus)]
+	if !ok {
+		tmpl = t.templates[defaultTemplateKey]
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.subject.Execute(&buf, msg); err != nil {
+		return msg, errors.Wrap(err, "notification.Render, unable to render subject")
+	}
+	//subject goes to mail header, so it must be single line
+	msg.Subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	buf.Reset()
+	if err := tmpl.body.Execute(&buf, msg); err != nil {
+		return msg, errors.Wrap(err, "notification.Render, unable to render text")
+	}
+	msg.Text = strings.TrimSpace(buf.String())
+
+	return msg, nil
+}
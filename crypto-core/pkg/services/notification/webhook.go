@@ -0,0 +1,82 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// WebhookSignatureHeader holds hex hmac-sha256 of request body keyed with webhook secret
+	WebhookSignatureHeader = "X-Signature"
+	// WebhookEventHeader holds event id, receivers use it to drop duplicates of retried deliveries
+	WebhookEventHeader = "X-Event-Id"
+)
+
+type webhookDeliverer struct {
+	url    string
+	secret []byte
+	client *http.Client
+}
+
+func (d *webhookDeliverer) Channel() string {
+	return "webhook"
+}
+
+func (d *webhookDeliverer) Deliver(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return &PermanentError{Err: errors.Wrap(err, "webhookDeliverer.Deliver, unable to marshal message")}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return &PermanentError{Err: errors.Wrap(err, "webhookDeliverer.Deliver, unable to build request")}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, msg.Event.ID)
+
+	if len(d.secret) > 0 {
+		mac := hmac.New(sha256.New, d.secret)
+		mac.Write(body)
+		req.Header.Set(WebhookSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "webhookDeliverer.Deliver, request failed")
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusRequestTimeout:
+		return &PermanentError{Err: errors.Errorf("webhookDeliverer.Deliver, rejected with status %d", resp.StatusCode)}
+	default:
+		return errors.Errorf("webhookDeliverer.Deliver, failed with status %d", resp.StatusCode)
+	}
+}
+
+// NewWebhookDeliverer posts messages as json to target, body is signed if secret is not empty
+func NewWebhookDeliverer(target, secret string) (Deliverer, error) {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("notification.NewWebhookDeliverer, target should be an http or https url")
+	}
+
+	return &webhookDeliverer{
+		url:    target,
+		secret: []byte(secret),
+		client: &http.Client{},
+	}, nil
+}
@@ -0,0 +1,110 @@
+package payout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/helpers/currency"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusBatched Status = "batched"
+	StatusPaid    Status = "paid"
+)
+
+type BatchStatus string
+
+const (
+	BatchOpen     BatchStatus = "open"
+	BatchExported BatchStatus = "exported"
+	BatchPaid     BatchStatus = "paid"
+)
+
+// MaxBatchSize limits the number of payouts in one batch
+const MaxBatchSize = 500
+
+var (
+	ErrBatchNotFound  = errors.New("payout batch not found")
+	ErrBatchPaid      = errors.New("payout batch is already paid")
+	ErrNothingToBatch = errors.New("no pending payouts among selected")
+	ErrEmptyReference = errors.New("payment reference cannot be empty")
+)
+
+// Payout is a reward entry of approved mission request, Amount is in ATx units.
+// UserEmail is e-mail of User, masked for readers not allowed to see it
+type Payout struct {
+	ID            int64                    `db:"id" json:"id"`
+	UserMissionID int64                    `db:"userMissionId" json:"userMissionId"`
+	UserID        int64                    `db:"userId" json:"userId"`
+	User          contributor.UserIdentity `db:"user" json:"-"`
+	UserEmail     string                   `db:"-" json:"userEmail"`
+	MissionID     int64                    `db:"missionId" json:"missionId"`
+	Mission       string                   `db:"mission" json:"mission"`
+	Amount        decimal.Decimal          `db:"amount" json:"amount"`
+	Status        Status                   `db:"status" json:"status"`
+	BatchID       *int64                   `db:"batchId" json:"batchId"`
+	CreatedAt     time.Time                `db:"createdAt" json:"createdAt"`
+	PaidAt        *time.Time               `db:"paidAt" json:"paidAt"`
+}
+
+// Tokens returns payout amount in tokens
+func (p Payout) Tokens() decimal.Decimal {
+	return currency.DenormalizeATx(p.Amount)
+}
+
+type Batch struct {
+	ID         int64           `db:"id" json:"id"`
+	Status     BatchStatus     `db:"status" json:"status"`
+	CreatedBy  int64           `db:"createdBy" json:"createdBy"`
+	PaidBy     *int64          `db:"paidBy" json:"paidBy"`
+	Reference  string          `db:"reference" json:"reference"`
+	CreatedAt  time.Time       `db:"createdAt" json:"createdAt"`
+	ExportedAt *time.Time      `db:"exportedAt" json:"exportedAt"`
+	PaidAt     *time.Time      `db:"paidAt" json:"paidAt"`
+	Count      int             `db:"count" json:"count"`
+	Total      decimal.Decimal `db:"total" json:"total"`
+}
+
+// TotalTokens returns batch total in tokens
+func (b Batch) TotalTokens() decimal.Decimal {
+	return currency.DenormalizeATx(b.Total)
+}
+
+// BatchSizeError is returned when more payouts than MaxBatchSize are selected for one batch
+type BatchSizeError struct {
+	Selected int
+}
+
+func (e *BatchSizeError) Error() string {
+	return fmt.Sprintf("no more than %d payouts can be batched at once, %d selected", MaxBatchSize, e.Selected)
+}
+
+type Repository interface {
+	// List returns payouts in status, of batch if batchID is not zero
+	List(ctx context.Context, status Status, batchID int64, limit int) ([]Payout, error)
+	ListBatches(ctx context.Context, limit int) ([]Batch, error)
+	GetBatch(ctx context.Context, id int64) (*Batch, error)
+	// CreateBatch puts pending payouts among ids into a new batch, returns ErrNothingToBatch if there are none
+	CreateBatch(ctx context.Context, actorID int64, ids []int64) (int64, error)
+	MarkBatchExported(ctx context.Context, id int64) error
+	// MarkBatchPaid marks batch, its payouts and their mission requests paid in one transaction,
+	// contributors of requests moved to paid status are notified through notification outbox
+	MarkBatchPaid(ctx context.Context, id int64, actorID int64, reference string) error
+}
+
+type Service interface {
+	// ListPayouts masks e-mails of users if maskEmails is set
+	ListPayouts(ctx context.Context, status Status, batchID int64, maskEmails bool) ([]Payout, error)
+	ListBatches(ctx context.Context) ([]Batch, error)
+	CreateBatch(ctx context.Context, actorID int64, ids []int64) (*Batch, error)
+	// ExportBatch returns batch with its payouts and marks it exported
+	ExportBatch(ctx context.Context, id int64) (*Batch, []Payout, error)
+	MarkBatchPaid(ctx context.Context, id int64, actorID int64, reference string) error
+}
@@ -0,0 +1,290 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/bfg-dev/crypto-core/pkg/helpers/db"
+	"github.com/bfg-dev/crypto-core/pkg/metrics"
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/bfg-dev/crypto-core/pkg/services/notification"
+	notificationPostgres "github.com/bfg-dev/crypto-core/pkg/services/notification/postgres"
+	"github.com/bfg-dev/crypto-core/pkg/services/payout"
+	"github.com/bfg-dev/crypto-core/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const batchColumns = `
+	b.id, b.status, b."createdBy", b."paidBy", b.reference, b."createdAt", b."exportedAt", b."paidAt",
+	COUNT(p.id) AS count, COALESCE(SUM(p.amount), 0) AS total`
+
+type payoutRepository struct {
+	db *sqlx.DB
+}
+
+func (repo *payoutRepository) List(ctx context.Context, status payout.Status, batchID int64, limit int) (_ []payout.Payout, err error) {
+	defer metrics.ObserveDBQuery("payoutRepository.List", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "payoutRepository.List", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	payouts := make([]payout.Payout, 0)
+	err = sqlx.SelectContext(ctx, repo.db, &payouts, `
+		SELECT
+			p.id, p."userMissionId", p."userId", p."missionId", m.title AS mission,
+			u.id AS "user.id", u.firstname AS "user.firstname", u.lastname AS "user.lastname", u.email AS "user.email",
+			p.amount, p.status, p."batchId", p."createdAt", p."paidAt"
+		FROM
+			"ccPayouts" p
+		JOIN
+			"users" u ON p."userId" = u.id
+		JOIN
+			"ccMissions" m ON p."missionId" = m.id
+		WHERE
+			p.status = $1 AND ($2::bigint = 0 OR p."batchId" = $2)
+		ORDER BY
+			p.id
+		LIMIT $3`, status, batchID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "payoutRepository.List, unable to get payouts")
+	}
+
+	return payouts, nil
+}
+
+func (repo *payoutRepository) ListBatches(ctx context.Context, limit int) (_ []payout.Batch, err error) {
+	defer metrics.ObserveDBQuery("payoutRepository.ListBatches", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "payoutRepository.ListBatches", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	batches := make([]payout.Batch, 0)
+	err = sqlx.SelectContext(ctx, repo.db, &batches, `
+		SELECT `+batchColumns+`
+		FROM
+			"ccPayoutBatches" b
+		LEFT JOIN
+			"ccPayouts" p ON p."batchId" = b.id
+		GROUP BY
+			b.id
+		ORDER BY
+			b.id DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "payoutRepository.ListBatches, unable to get batches")
+	}
+
+	return batches, nil
+}
+
+func (repo *payoutRepository) GetBatch(ctx context.Context, id int64) (_ *payout.Batch, err error) {
+	defer metrics.ObserveDBQuery("payoutRepository.GetBatch", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "payoutRepository.GetBatch", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	rs := payout.Batch{}
+	err = repo.db.QueryRowxContext(ctx, `
+		SELECT `+batchColumns+`
+		FROM
+			"ccPayoutBatches" b
+		LEFT JOIN
+			"ccPayouts" p ON p."batchId" = b.id
+		WHERE
+			b.id = $1
+		GROUP BY
+			b.id`, id).StructScan(&rs)
+	if err != nil {
+		return nil, db.EmptyOrError(err, "payoutRepository.GetBatch, unable to get batch")
+	}
+
+	return &rs, nil
+}
+
+func (repo *payoutRepository) CreateBatch(ctx context.Context, actorID int64, ids []int64) (_ int64, err error) {
+	defer metrics.ObserveDBQuery("payoutRepository.CreateBatch", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "payoutRepository.CreateBatch", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "payoutRepository.CreateBatch, unable to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var batchID int64
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO
+			"ccPayoutBatches" (status, "createdBy")
+		VALUES
+			($1, $2)
+		RETURNING id`, payout.BatchOpen, actorID).Scan(&batchID)
+	if err != nil {
+		return 0, errors.Wrap(err, "payoutRepository.CreateBatch, unable to insert batch")
+	}
+
+	query, args, err := sqlx.In(`
+		UPDATE
+			"ccPayouts"
+		SET
+			status=?,
+			"batchId"=?
+		WHERE
+			status=? AND id IN (?)`, payout.StatusBatched, batchID, payout.StatusPending, ids)
+	if err != nil {
+		return 0, errors.Wrap(err, "payoutRepository.CreateBatch, unable to build query")
+	}
+
+	res, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "payoutRepository.CreateBatch, unable to batch payouts")
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "payoutRepository.CreateBatch, unable to get affected rows")
+	}
+
+	if affected == 0 {
+		return 0, payout.ErrNothingToBatch
+	}
+
+	return batchID, errors.Wrap(tx.Commit(), "payoutRepository.CreateBatch, unable to commit")
+}
+
+func (repo *payoutRepository) MarkBatchExported(ctx context.Context, id int64) (err error) {
+	defer metrics.ObserveDBQuery("payoutRepository.MarkBatchExported", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "payoutRepository.MarkBatchExported", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	_, err = repo.db.ExecContext(ctx, `
+		UPDATE
+			"ccPayoutBatches"
+		SET
+			status=$3,
+			"exportedAt"=now()
+		WHERE
+			id=$1 AND status=$2`, id, payout.BatchOpen, payout.BatchExported)
+	if err != nil {
+		return errors.Wrap(err, "payoutRepository.MarkBatchExported, unable to update batch")
+	}
+
+	return nil
+}
+
+func (repo *payoutRepository) MarkBatchPaid(ctx context.Context, id int64, actorID int64, reference string) (err error) {
+	defer metrics.ObserveDBQuery("payoutRepository.MarkBatchPaid", time.Now())
+	ctx, span := tracing.StartSpan(ctx, "payoutRepository.MarkBatchPaid", attribute.String("db.system", "postgresql"))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	tx, err := repo.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "payoutRepository.MarkBatchPaid, unable to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var status payout.BatchStatus
+	err = tx.QueryRowxContext(ctx, `SELECT status FROM "ccPayoutBatches" WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return payout.ErrBatchNotFound
+	}
+	if err != nil {
+		return errors.Wrap(err, "payoutRepository.MarkBatchPaid, unable to get batch")
+	}
+
+	if status == payout.BatchPaid {
+		return payout.ErrBatchPaid
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE
+			"ccPayoutBatches"
+		SET
+			status=$2,
+			"paidBy"=$3,
+			reference=$4,
+			"paidAt"=now()
+		WHERE
+			id=$1`, id, payout.BatchPaid, actorID, reference)
+	if err != nil {
+		return errors.Wrap(err, "payoutRepository.MarkBatchPaid, unable to update batch")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE
+			"ccPayouts"
+		SET
+			status=$2,
+			"paidAt"=now()
+		WHERE
+			"batchId"=$1`, id, payout.StatusPaid)
+	if err != nil {
+		return errors.Wrap(err, "payoutRepository.MarkBatchPaid, unable to update payouts")
+	}
+
+	//history goes first to keep old status of requests
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO
+			"ccUserMissionStatusHistory" ("userMissionId", "actorId", "oldStatus", "newStatus", "comment")
+		SELECT
+			us.id, $2, us.status, $4, $5
+		FROM
+			"ccUserMissions" us
+		JOIN
+			"ccPayouts" p ON p."userMissionId" = us.id
+		WHERE
+			p."batchId"=$1 AND us.status=$3`,
+		id, actorID, contributor.StatusApproved, contributor.StatusPaid, fmt.Sprintf("payout batch #%d: %s", id, reference))
+	if err != nil {
+		return errors.Wrap(err, "payoutRepository.MarkBatchPaid, unable to write status history")
+	}
+
+	userMissionIDs := make([]int64, 0)
+	err = sqlx.SelectContext(ctx, tx, &userMissionIDs, `
+		UPDATE
+			"ccUserMissions" us
+		SET
+			status=$3
+		FROM
+			"ccPayouts" p
+		WHERE
+			p."userMissionId" = us.id AND p."batchId"=$1 AND us.status=$2
+		RETURNING
+			us.id`,
+		id, contributor.StatusApproved, contributor.StatusPaid)
+	if err != nil {
+		return errors.Wrap(err, "payoutRepository.MarkBatchPaid, unable to update mission requests")
+	}
+
+	events := make([]notification.Event, len(userMissionIDs))
+	for i, userMissionID := range userMissionIDs {
+		events[i] = notification.NewStatusChangedEvent(ctx, userMissionID, actorID, contributor.StatusApproved, contributor.StatusPaid)
+	}
+
+	if err = notificationPostgres.WriteOutbox(ctx, tx, events...); err != nil {
+		return errors.Wrap(err, "payoutRepository.MarkBatchPaid, unable to write notifications")
+	}
+
+	return errors.Wrap(tx.Commit(), "payoutRepository.MarkBatchPaid, unable to commit")
+}
+
+func NewPayoutRepository(db *sqlx.DB) (payout.Repository, error) {
+	if db == nil {
+		return nil, errors.New("NewPayoutRepository: db connection is empty")
+	}
+
+	return &payoutRepository{db}, nil
+}
@@ -0,0 +1,55 @@
+//go:build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/bfg-dev/crypto-core/pkg/services/payout"
+	"github.com/bfg-dev/crypto-core/pkg/testutil"
+)
+
+func TestListUserWithoutIdentity(t *testing.T) {
+	db := testutil.DB(t)
+	ctx := context.Background()
+
+	missionID := testutil.CreateMission(t, db, "payout identity test")
+	userID := testutil.CreateUser(t, db, nil, nil, nil)
+	userMissionID := testutil.CreateRequest(t, db, userID, missionID, contributor.StatusApproved)
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO
+			"ccPayouts" ("userMissionId", "userId", "missionId", "amount")
+		VALUES
+			($1, $2, $3, 1)`, userMissionID, userID, missionID)
+	if err != nil {
+		t.Fatalf("unable to insert payout: %v", err)
+	}
+
+	repo, err := NewPayoutRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payouts, err := repo.List(ctx, payout.StatusPending, 0, 1000)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	for _, p := range payouts {
+		if p.UserMissionID != userMissionID {
+			continue
+		}
+
+		if p.User.ID != userID {
+			t.Errorf("got user %d, want %d", p.User.ID, userID)
+		}
+		if p.User.FirstName != nil || p.User.LastName != nil || p.User.Email != nil {
+			t.Errorf("got identity %+v, want NULL names and e-mail", p.User)
+		}
+		return
+	}
+	t.Fatalf("payout of request %d is not listed", userMissionID)
+}
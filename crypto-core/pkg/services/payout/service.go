@@ -0,0 +1,140 @@
+package payout
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bfg-dev/crypto-core/pkg/services/contributor"
+	"github.com/pkg/errors"
+)
+
+// listLimit limits payouts and batches shown at once
+const listLimit = 1000
+
+type service struct {
+	repo Repository
+}
+
+func (s *service) ListPayouts(ctx context.Context, status Status, batchID int64, maskEmails bool) ([]Payout, error) {
+	payouts, err := s.repo.List(ctx, status, batchID, listLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "payout.ListPayouts, unable to get payouts")
+	}
+	setEmails(payouts, maskEmails)
+	return payouts, nil
+}
+
+func (s *service) ListBatches(ctx context.Context) ([]Batch, error) {
+	batches, err := s.repo.ListBatches(ctx, listLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "payout.ListBatches, unable to get batches")
+	}
+	return batches, nil
+}
+
+func (s *service) CreateBatch(ctx context.Context, actorID int64, ids []int64) (*Batch, error) {
+	if actorID == 0 {
+		return nil, errors.New("payout.CreateBatch, actor cannot be empty")
+	}
+
+	if len(ids) == 0 {
+		return nil, ErrNothingToBatch
+	}
+
+	if len(ids) > MaxBatchSize {
+		return nil, &BatchSizeError{Selected: len(ids)}
+	}
+
+	id, err := s.repo.CreateBatch(ctx, actorID, ids)
+	if err == ErrNothingToBatch {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "payout.CreateBatch, unable to create batch")
+	}
+
+	return s.getBatch(ctx, id)
+}
+
+func (s *service) ExportBatch(ctx context.Context, id int64) (*Batch, []Payout, error) {
+	batch, err := s.getBatch(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := StatusBatched
+	if batch.Status == BatchPaid {
+		status = StatusPaid
+	}
+
+	payouts, err := s.repo.List(ctx, status, id, MaxBatchSize)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "payout.ExportBatch, unable to get payouts")
+	}
+	//batches are exported by admins to pay users, so e-mails are kept
+	setEmails(payouts, false)
+
+	if batch.Status == BatchOpen {
+		if err = s.repo.MarkBatchExported(ctx, id); err != nil {
+			return nil, nil, errors.Wrap(err, "payout.ExportBatch, unable to mark batch exported")
+		}
+	}
+
+	return batch, payouts, nil
+}
+
+func (s *service) MarkBatchPaid(ctx context.Context, id int64, actorID int64, reference string) error {
+	if actorID == 0 {
+		return errors.New("payout.MarkBatchPaid, actor cannot be empty")
+	}
+
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return ErrEmptyReference
+	}
+
+	err := s.repo.MarkBatchPaid(ctx, id, actorID, reference)
+	if err == ErrBatchNotFound || err == ErrBatchPaid {
+		return err
+	}
+	if err != nil {
+		return errors.Wrap(err, "payout.MarkBatchPaid, unable to mark batch paid")
+	}
+	return nil
+}
+
+func (s *service) getBatch(ctx context.Context, id int64) (*Batch, error) {
+	batch, err := s.repo.GetBatch(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "payout.getBatch, unable to get batch")
+	}
+
+	if batch == nil {
+		return nil, ErrBatchNotFound
+	}
+	return batch, nil
+}
+
+func NewService(repo Repository) (Service, error) {
+	if repo == nil {
+		return nil, errors.New("payout.NewService, repo cannot be empty")
+	}
+
+	return &service{
+		repo: repo,
+	}, nil
+}
+
+// setEmails fills UserEmail of payouts, users without e-mail get empty one
+func setEmails(payouts []Payout, mask bool) {
+	for i := range payouts {
+		email := ""
+		if payouts[i].User.Email != nil {
+			email = strings.TrimSpace(*payouts[i].User.Email)
+		}
+		if email != "" && mask {
+			email = contributor.MaskEmail(email)
+		}
+		payouts[i].UserEmail = email
+	}
+}
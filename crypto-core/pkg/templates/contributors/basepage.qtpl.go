@@ -34,129 +34,179 @@ type Page interface {
 	StreamBody(qw422016 *qt422016.Writer)
 	//line contributors/basepage.qtpl:4
 	WriteBody(qq422016 qtio422016.Writer)
+	//line contributors/basepage.qtpl:4
+	CSRFField() string
+	//line contributors/basepage.qtpl:4
+	StreamCSRFField(qw422016 *qt422016.Writer)
+	//line contributors/basepage.qtpl:4
+	WriteCSRFField(qq422016 qtio422016.Writer)
 //line contributors/basepage.qtpl:4
 }
 
 // Page prints a page implementing Page interface.
 
-//line contributors/basepage.qtpl:12
+//line contributors/basepage.qtpl:13
 func StreamPageTemplate(qw422016 *qt422016.Writer, p Page) {
-	//line contributors/basepage.qtpl:12
+	//line contributors/basepage.qtpl:13
 	qw422016.N().S(`
 <html>
     <link type="text/css" rel="stylesheet" href="/static/css/default.css">
 	<head>
 		<title>`)
-	//line contributors/basepage.qtpl:16
+	//line contributors/basepage.qtpl:17
 	p.StreamTitle(qw422016)
-	//line contributors/basepage.qtpl:16
+	//line contributors/basepage.qtpl:17
 	qw422016.N().S(`</title>
 	</head>
 	<body>
 		<h1>Админка контрибьюторов</h1>
 		`)
-	//line contributors/basepage.qtpl:20
+	//line contributors/basepage.qtpl:21
 	p.StreamBody(qw422016)
-	//line contributors/basepage.qtpl:20
+	//line contributors/basepage.qtpl:21
 	qw422016.N().S(`
 	</body>
 </html>
 `)
-//line contributors/basepage.qtpl:23
+//line contributors/basepage.qtpl:24
 }
 
-//line contributors/basepage.qtpl:23
+//line contributors/basepage.qtpl:24
 func WritePageTemplate(qq422016 qtio422016.Writer, p Page) {
-	//line contributors/basepage.qtpl:23
+	//line contributors/basepage.qtpl:24
 	qw422016 := qt422016.AcquireWriter(qq422016)
-	//line contributors/basepage.qtpl:23
+	//line contributors/basepage.qtpl:24
 	StreamPageTemplate(qw422016, p)
-	//line contributors/basepage.qtpl:23
+	//line contributors/basepage.qtpl:24
 	qt422016.ReleaseWriter(qw422016)
-//line contributors/basepage.qtpl:23
+//line contributors/basepage.qtpl:24
 }
 
-//line contributors/basepage.qtpl:23
+//line contributors/basepage.qtpl:24
 func PageTemplate(p Page) string {
-	//line contributors/basepage.qtpl:23
+	//line contributors/basepage.qtpl:24
 	qb422016 := qt422016.AcquireByteBuffer()
-	//line contributors/basepage.qtpl:23
+	//line contributors/basepage.qtpl:24
 	WritePageTemplate(qb422016, p)
-	//line contributors/basepage.qtpl:23
+	//line contributors/basepage.qtpl:24
 	qs422016 := string(qb422016.B)
-	//line contributors/basepage.qtpl:23
+	//line contributors/basepage.qtpl:24
 	qt422016.ReleaseByteBuffer(qb422016)
-	//line contributors/basepage.qtpl:23
+	//line contributors/basepage.qtpl:24
 	return qs422016
-//line contributors/basepage.qtpl:23
+//line contributors/basepage.qtpl:24
 }
 
 // Base page implementation. Other pages may inherit from it if they need
 // overriding only certain Page methods
 
-//line contributors/basepage.qtpl:28
-type BasePage struct{}
+//line contributors/basepage.qtpl:30
+type BasePage struct {
+	// CSRFToken of the admin session, handlers set it for pages with forms
+	CSRFToken string
+}
 
-//line contributors/basepage.qtpl:29
+//line contributors/basepage.qtpl:35
 func (p *BasePage) StreamTitle(qw422016 *qt422016.Writer) {
-//line contributors/basepage.qtpl:29
-qw422016.N().S(`This is a base title`) }
+	//line contributors/basepage.qtpl:35
+	qw422016.N().S(`This is a base title`)
+//line contributors/basepage.qtpl:35
+}
 
-//line contributors/basepage.qtpl:29
-//line contributors/basepage.qtpl:29
+//line contributors/basepage.qtpl:35
 func (p *BasePage) WriteTitle(qq422016 qtio422016.Writer) {
-	//line contributors/basepage.qtpl:29
+	//line contributors/basepage.qtpl:35
 	qw422016 := qt422016.AcquireWriter(qq422016)
-	//line contributors/basepage.qtpl:29
+	//line contributors/basepage.qtpl:35
 	p.StreamTitle(qw422016)
-	//line contributors/basepage.qtpl:29
+	//line contributors/basepage.qtpl:35
 	qt422016.ReleaseWriter(qw422016)
-//line contributors/basepage.qtpl:29
+//line contributors/basepage.qtpl:35
 }
 
-//line contributors/basepage.qtpl:29
+//line contributors/basepage.qtpl:35
 func (p *BasePage) Title() string {
-	//line contributors/basepage.qtpl:29
+	//line contributors/basepage.qtpl:35
 	qb422016 := qt422016.AcquireByteBuffer()
-	//line contributors/basepage.qtpl:29
+	//line contributors/basepage.qtpl:35
 	p.WriteTitle(qb422016)
-	//line contributors/basepage.qtpl:29
+	//line contributors/basepage.qtpl:35
 	qs422016 := string(qb422016.B)
-	//line contributors/basepage.qtpl:29
+	//line contributors/basepage.qtpl:35
 	qt422016.ReleaseByteBuffer(qb422016)
-	//line contributors/basepage.qtpl:29
+	//line contributors/basepage.qtpl:35
 	return qs422016
-//line contributors/basepage.qtpl:29
+//line contributors/basepage.qtpl:35
 }
 
-//line contributors/basepage.qtpl:30
+//line contributors/basepage.qtpl:36
 func (p *BasePage) StreamBody(qw422016 *qt422016.Writer) {
-//line contributors/basepage.qtpl:30
-qw422016.N().S(`This is a base body`) }
+	//line contributors/basepage.qtpl:36
+	qw422016.N().S(`This is a base body`)
+//line contributors/basepage.qtpl:36
+}
 
-//line contributors/basepage.qtpl:30
-//line contributors/basepage.qtpl:30
+//line contributors/basepage.qtpl:36
 func (p *BasePage) WriteBody(qq422016 qtio422016.Writer) {
-	//line contributors/basepage.qtpl:30
+	//line contributors/basepage.qtpl:36
 	qw422016 := qt422016.AcquireWriter(qq422016)
-	//line contributors/basepage.qtpl:30
+	//line contributors/basepage.qtpl:36
 	p.StreamBody(qw422016)
-	//line contributors/basepage.qtpl:30
+	//line contributors/basepage.qtpl:36
 	qt422016.ReleaseWriter(qw422016)
-//line contributors/basepage.qtpl:30
+//line contributors/basepage.qtpl:36
 }
 
-//line contributors/basepage.qtpl:30
+//line contributors/basepage.qtpl:36
 func (p *BasePage) Body() string {
-	//line contributors/basepage.qtpl:30
+	//line contributors/basepage.qtpl:36
 	qb422016 := qt422016.AcquireByteBuffer()
-	//line contributors/basepage.qtpl:30
+	//line contributors/basepage.qtpl:36
 	p.WriteBody(qb422016)
-	//line contributors/basepage.qtpl:30
+	//line contributors/basepage.qtpl:36
 	qs422016 := string(qb422016.B)
-	//line contributors/basepage.qtpl:30
+	//line contributors/basepage.qtpl:36
 	qt422016.ReleaseByteBuffer(qb422016)
-	//line contributors/basepage.qtpl:30
+	//line contributors/basepage.qtpl:36
 	return qs422016
-//line contributors/basepage.qtpl:30
+//line contributors/basepage.qtpl:36
+}
+
+// CSRFField prints hidden csrf token input, every form changing state must contain it.
+
+//line contributors/basepage.qtpl:39
+func (p *BasePage) StreamCSRFField(qw422016 *qt422016.Writer) {
+	//line contributors/basepage.qtpl:39
+	qw422016.N().S(`<input type="hidden" name="csrf_token" value="`)
+	//line contributors/basepage.qtpl:39
+	qw422016.E().S(p.CSRFToken)
+	//line contributors/basepage.qtpl:39
+	qw422016.N().S(`">`)
+//line contributors/basepage.qtpl:39
+}
+
+//line contributors/basepage.qtpl:39
+func (p *BasePage) WriteCSRFField(qq422016 qtio422016.Writer) {
+	//line contributors/basepage.qtpl:39
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/basepage.qtpl:39
+	p.StreamCSRFField(qw422016)
+	//line contributors/basepage.qtpl:39
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/basepage.qtpl:39
+}
+
+//line contributors/basepage.qtpl:39
+func (p *BasePage) CSRFField() string {
+	//line contributors/basepage.qtpl:39
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/basepage.qtpl:39
+	p.WriteCSRFField(qb422016)
+	//line contributors/basepage.qtpl:39
+	qs422016 := string(qb422016.B)
+	//line contributors/basepage.qtpl:39
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/basepage.qtpl:39
+	return qs422016
+//line contributors/basepage.qtpl:39
 }
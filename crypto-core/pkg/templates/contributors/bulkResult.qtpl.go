@@ -0,0 +1,189 @@
+// This file is automatically generated by qtc from "bulkResult.qtpl".
+// See https://github.com/valyala/quicktemplate for details.
+
+// Bulk status change report page. Implements Page methods.
+//
+
+//line contributors/bulkResult.qtpl:3
+package contributors
+
+//line contributors/bulkResult.qtpl:3
+import (
+	contributor "github.com/bfg-dev/crypto-core/pkg/services/contributor"
+)
+
+//line contributors/bulkResult.qtpl:8
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line contributors/bulkResult.qtpl:8
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line contributors/bulkResult.qtpl:9
+type BulkResultPage struct {
+	BasePage
+	Status string
+	Result *contributor.BulkResult
+}
+
+//line contributors/bulkResult.qtpl:16
+func (p *BulkResultPage) StreamTitle(qw422016 *qt422016.Writer) {
+	//line contributors/bulkResult.qtpl:16
+	qw422016.N().S(`
+	Результат массового изменения
+`)
+//line contributors/bulkResult.qtpl:18
+}
+
+//line contributors/bulkResult.qtpl:18
+func (p *BulkResultPage) WriteTitle(qq422016 qtio422016.Writer) {
+	//line contributors/bulkResult.qtpl:18
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/bulkResult.qtpl:18
+	p.StreamTitle(qw422016)
+	//line contributors/bulkResult.qtpl:18
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/bulkResult.qtpl:18
+}
+
+//line contributors/bulkResult.qtpl:18
+func (p *BulkResultPage) Title() string {
+	//line contributors/bulkResult.qtpl:18
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/bulkResult.qtpl:18
+	p.WriteTitle(qb422016)
+	//line contributors/bulkResult.qtpl:18
+	qs422016 := string(qb422016.B)
+	//line contributors/bulkResult.qtpl:18
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/bulkResult.qtpl:18
+	return qs422016
+//line contributors/bulkResult.qtpl:18
+}
+
+//line contributors/bulkResult.qtpl:20
+func (p *BulkResultPage) StreamBody(qw422016 *qt422016.Writer) {
+	//line contributors/bulkResult.qtpl:20
+	qw422016.N().S(`
+
+    <h2>Массовое изменение статуса на «`)
+	//line contributors/bulkResult.qtpl:22
+	qw422016.E().S(p.Status)
+	//line contributors/bulkResult.qtpl:22
+	qw422016.N().S(`»</h2>
+
+    `)
+	//line contributors/bulkResult.qtpl:24
+	if p.Result.Applied {
+		//line contributors/bulkResult.qtpl:24
+		qw422016.N().S(`
+        <p>Все запросы изменены.</p>
+    `)
+	//line contributors/bulkResult.qtpl:26
+	} else {
+		//line contributors/bulkResult.qtpl:26
+		qw422016.N().S(`
+        <p>Ни один запрос не изменён, исправьте ошибки и повторите действие.</p>
+    `)
+	//line contributors/bulkResult.qtpl:28
+	}
+	//line contributors/bulkResult.qtpl:28
+	qw422016.N().S(`
+
+	<table>
+	    <thead>
+	        <tr>
+	            <th>ID запроса</th>
+	            <th>Результат</th>
+	        </tr>
+	    </thead>
+	    <tbody>
+	`)
+	//line contributors/bulkResult.qtpl:38
+	for _, item := range p.Result.Items {
+		//line contributors/bulkResult.qtpl:38
+		qw422016.N().S(`
+	    <tr>
+	        <td><a href="/admin/UserRequestStatusHistory?id=`)
+		//line contributors/bulkResult.qtpl:40
+		qw422016.N().DL(item.ID)
+		//line contributors/bulkResult.qtpl:40
+		qw422016.N().S(`">`)
+		//line contributors/bulkResult.qtpl:40
+		qw422016.N().DL(item.ID)
+		//line contributors/bulkResult.qtpl:40
+		qw422016.N().S(`</a></td>
+	        <td>
+	        `)
+		//line contributors/bulkResult.qtpl:42
+		if item.Applied {
+			//line contributors/bulkResult.qtpl:42
+			qw422016.N().S(`
+	            Изменён
+	        `)
+		//line contributors/bulkResult.qtpl:44
+		} else if item.Error != "" {
+			//line contributors/bulkResult.qtpl:44
+			qw422016.N().S(`
+	            `)
+			//line contributors/bulkResult.qtpl:45
+			qw422016.E().S(item.Error)
+			//line contributors/bulkResult.qtpl:45
+			qw422016.N().S(`
+	        `)
+		//line contributors/bulkResult.qtpl:46
+		} else {
+			//line contributors/bulkResult.qtpl:46
+			qw422016.N().S(`
+	            Не изменён из-за ошибок в других запросах
+	        `)
+		//line contributors/bulkResult.qtpl:48
+		}
+		//line contributors/bulkResult.qtpl:48
+		qw422016.N().S(`
+	        </td>
+	    </tr>
+	`)
+	//line contributors/bulkResult.qtpl:51
+	}
+	//line contributors/bulkResult.qtpl:51
+	qw422016.N().S(`
+	    </tbody>
+	</table>
+
+    <p><a href="/admin/NewUserMissionRequests">Вернуться к списку запросов</a></p>
+`)
+//line contributors/bulkResult.qtpl:56
+}
+
+//line contributors/bulkResult.qtpl:56
+func (p *BulkResultPage) WriteBody(qq422016 qtio422016.Writer) {
+	//line contributors/bulkResult.qtpl:56
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/bulkResult.qtpl:56
+	p.StreamBody(qw422016)
+	//line contributors/bulkResult.qtpl:56
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/bulkResult.qtpl:56
+}
+
+//line contributors/bulkResult.qtpl:56
+func (p *BulkResultPage) Body() string {
+	//line contributors/bulkResult.qtpl:56
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/bulkResult.qtpl:56
+	p.WriteBody(qb422016)
+	//line contributors/bulkResult.qtpl:56
+	qs422016 := string(qb422016.B)
+	//line contributors/bulkResult.qtpl:56
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/bulkResult.qtpl:56
+	return qs422016
+//line contributors/bulkResult.qtpl:56
+}
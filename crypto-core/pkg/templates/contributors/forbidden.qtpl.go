@@ -0,0 +1,106 @@
+// This file is automatically generated by qtc from "forbidden.qtpl".
+// See https://github.com/valyala/quicktemplate for details.
+
+// Access denied page template. Implements Page methods.
+//
+
+//line contributors/forbidden.qtpl:3
+package contributors
+
+//line contributors/forbidden.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line contributors/forbidden.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line contributors/forbidden.qtpl:4
+type ForbiddenPage struct {
+	BasePage
+	Message string
+}
+
+//line contributors/forbidden.qtpl:10
+func (p *ForbiddenPage) StreamTitle(qw422016 *qt422016.Writer) {
+	//line contributors/forbidden.qtpl:10
+	qw422016.N().S(`
+	Доступ запрещён
+`)
+//line contributors/forbidden.qtpl:12
+}
+
+//line contributors/forbidden.qtpl:12
+func (p *ForbiddenPage) WriteTitle(qq422016 qtio422016.Writer) {
+	//line contributors/forbidden.qtpl:12
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/forbidden.qtpl:12
+	p.StreamTitle(qw422016)
+	//line contributors/forbidden.qtpl:12
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/forbidden.qtpl:12
+}
+
+//line contributors/forbidden.qtpl:12
+func (p *ForbiddenPage) Title() string {
+	//line contributors/forbidden.qtpl:12
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/forbidden.qtpl:12
+	p.WriteTitle(qb422016)
+	//line contributors/forbidden.qtpl:12
+	qs422016 := string(qb422016.B)
+	//line contributors/forbidden.qtpl:12
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/forbidden.qtpl:12
+	return qs422016
+//line contributors/forbidden.qtpl:12
+}
+
+//line contributors/forbidden.qtpl:14
+func (p *ForbiddenPage) StreamBody(qw422016 *qt422016.Writer) {
+	//line contributors/forbidden.qtpl:14
+	qw422016.N().S(`
+
+    <h2>Доступ запрещён</h2>
+
+    <p>`)
+	//line contributors/forbidden.qtpl:18
+	qw422016.E().S(p.Message)
+	//line contributors/forbidden.qtpl:18
+	qw422016.N().S(`</p>
+
+    <p><a href="/admin/NewUserMissionRequests">Вернуться к списку запросов</a></p>
+`)
+//line contributors/forbidden.qtpl:21
+}
+
+//line contributors/forbidden.qtpl:21
+func (p *ForbiddenPage) WriteBody(qq422016 qtio422016.Writer) {
+	//line contributors/forbidden.qtpl:21
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/forbidden.qtpl:21
+	p.StreamBody(qw422016)
+	//line contributors/forbidden.qtpl:21
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/forbidden.qtpl:21
+}
+
+//line contributors/forbidden.qtpl:21
+func (p *ForbiddenPage) Body() string {
+	//line contributors/forbidden.qtpl:21
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/forbidden.qtpl:21
+	p.WriteBody(qb422016)
+	//line contributors/forbidden.qtpl:21
+	qs422016 := string(qb422016.B)
+	//line contributors/forbidden.qtpl:21
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/forbidden.qtpl:21
+	return qs422016
+//line contributors/forbidden.qtpl:21
+}
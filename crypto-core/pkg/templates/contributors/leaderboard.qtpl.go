@@ -0,0 +1,461 @@
+// This file is automatically generated by qtc from "leaderboard.qtpl".
+// See https://github.com/valyala/quicktemplate for details.
+
+// Contributor leaderboard and mission statistics pages. Implement Page methods.
+//
+
+//line contributors/leaderboard.qtpl:3
+package contributors
+
+//line contributors/leaderboard.qtpl:3
+import (
+	"fmt"
+
+	leaderboard "github.com/bfg-dev/crypto-core/pkg/services/leaderboard"
+)
+
+//line contributors/leaderboard.qtpl:10
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line contributors/leaderboard.qtpl:10
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line contributors/leaderboard.qtpl:11
+type LeaderboardPage struct {
+	BasePage
+	Board   *leaderboard.Leaderboard
+	Periods []leaderboard.Period
+}
+
+type MissionStatsPage struct {
+	BasePage
+	Report  *leaderboard.MissionReport
+	Periods []leaderboard.Period
+}
+
+func periodTitle(period leaderboard.Period) string {
+	if period.Days == 0 {
+		return "Всё время"
+	}
+	return fmt.Sprintf("%d дн.", period.Days)
+}
+
+//line contributors/leaderboard.qtpl:31
+func streamstatsMenu(qw422016 *qt422016.Writer, path string, current leaderboard.Period, periods []leaderboard.Period) {
+	//line contributors/leaderboard.qtpl:31
+	qw422016.N().S(`
+    <p>
+        <a href="/admin/stats/leaderboard?period=`)
+	//line contributors/leaderboard.qtpl:33
+	qw422016.N().U(current.Code)
+	//line contributors/leaderboard.qtpl:33
+	qw422016.N().S(`">Рейтинг участников</a>
+        <a href="/admin/stats/missions?period=`)
+	//line contributors/leaderboard.qtpl:34
+	qw422016.N().U(current.Code)
+	//line contributors/leaderboard.qtpl:34
+	qw422016.N().S(`">Статистика миссий</a>
+        <a href="/admin/NewUserMissionRequests">Запросы на миссии</a>
+    </p>
+    <p>
+        Период:
+    `)
+	//line contributors/leaderboard.qtpl:39
+	for _, period := range periods {
+		//line contributors/leaderboard.qtpl:39
+		qw422016.N().S(`
+        `)
+		//line contributors/leaderboard.qtpl:40
+		if period.Code == current.Code {
+			//line contributors/leaderboard.qtpl:40
+			qw422016.N().S(`
+            <b>`)
+			//line contributors/leaderboard.qtpl:41
+			qw422016.E().S(periodTitle(period))
+			//line contributors/leaderboard.qtpl:41
+			qw422016.N().S(`</b>
+        `)
+		//line contributors/leaderboard.qtpl:42
+		} else {
+			//line contributors/leaderboard.qtpl:42
+			qw422016.N().S(`
+            <a href="`)
+			//line contributors/leaderboard.qtpl:43
+			qw422016.E().S(path)
+			//line contributors/leaderboard.qtpl:43
+			qw422016.N().S(`?period=`)
+			//line contributors/leaderboard.qtpl:43
+			qw422016.N().U(period.Code)
+			//line contributors/leaderboard.qtpl:43
+			qw422016.N().S(`">`)
+			//line contributors/leaderboard.qtpl:43
+			qw422016.E().S(periodTitle(period))
+			//line contributors/leaderboard.qtpl:43
+			qw422016.N().S(`</a>
+        `)
+		//line contributors/leaderboard.qtpl:44
+		}
+		//line contributors/leaderboard.qtpl:44
+		qw422016.N().S(`
+    `)
+	//line contributors/leaderboard.qtpl:45
+	}
+	//line contributors/leaderboard.qtpl:45
+	qw422016.N().S(`
+    </p>
+`)
+//line contributors/leaderboard.qtpl:47
+}
+
+//line contributors/leaderboard.qtpl:47
+func writestatsMenu(qq422016 qtio422016.Writer, path string, current leaderboard.Period, periods []leaderboard.Period) {
+	//line contributors/leaderboard.qtpl:47
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/leaderboard.qtpl:47
+	streamstatsMenu(qw422016, path, current, periods)
+	//line contributors/leaderboard.qtpl:47
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/leaderboard.qtpl:47
+}
+
+//line contributors/leaderboard.qtpl:47
+func statsMenu(path string, current leaderboard.Period, periods []leaderboard.Period) string {
+	//line contributors/leaderboard.qtpl:47
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/leaderboard.qtpl:47
+	writestatsMenu(qb422016, path, current, periods)
+	//line contributors/leaderboard.qtpl:47
+	qs422016 := string(qb422016.B)
+	//line contributors/leaderboard.qtpl:47
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/leaderboard.qtpl:47
+	return qs422016
+//line contributors/leaderboard.qtpl:47
+}
+
+//line contributors/leaderboard.qtpl:49
+func (p *LeaderboardPage) StreamTitle(qw422016 *qt422016.Writer) {
+	//line contributors/leaderboard.qtpl:49
+	qw422016.N().S(`
+	Рейтинг участников
+`)
+//line contributors/leaderboard.qtpl:51
+}
+
+//line contributors/leaderboard.qtpl:51
+func (p *LeaderboardPage) WriteTitle(qq422016 qtio422016.Writer) {
+	//line contributors/leaderboard.qtpl:51
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/leaderboard.qtpl:51
+	p.StreamTitle(qw422016)
+	//line contributors/leaderboard.qtpl:51
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/leaderboard.qtpl:51
+}
+
+//line contributors/leaderboard.qtpl:51
+func (p *LeaderboardPage) Title() string {
+	//line contributors/leaderboard.qtpl:51
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/leaderboard.qtpl:51
+	p.WriteTitle(qb422016)
+	//line contributors/leaderboard.qtpl:51
+	qs422016 := string(qb422016.B)
+	//line contributors/leaderboard.qtpl:51
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/leaderboard.qtpl:51
+	return qs422016
+//line contributors/leaderboard.qtpl:51
+}
+
+//line contributors/leaderboard.qtpl:53
+func (p *LeaderboardPage) StreamBody(qw422016 *qt422016.Writer) {
+	//line contributors/leaderboard.qtpl:53
+	qw422016.N().S(`
+
+    <h2>Рейтинг участников</h2>
+
+    `)
+	//line contributors/leaderboard.qtpl:57
+	streamstatsMenu(qw422016, "/admin/stats/leaderboard", p.Board.Period, p.Periods)
+	//line contributors/leaderboard.qtpl:57
+	qw422016.N().S(`
+
+	<table>
+	    <thead>
+	        <tr>
+	            <th>Место</th>
+	            <th>Участник</th>
+	            <th>Заявок</th>
+	            <th>Одобрено</th>
+	            <th>Отклонено</th>
+	            <th>Доля одобренных</th>
+	            <th>Награды</th>
+	            <th>Серия, дней</th>
+	            <th>Лучшая серия</th>
+	            <th>&nbsp;</th>
+	        </tr>
+	    </thead>
+	    <tbody>
+	`)
+	//line contributors/leaderboard.qtpl:75
+	for _, item := range p.Board.Contributors {
+		//line contributors/leaderboard.qtpl:75
+		qw422016.N().S(`
+	    <tr>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:77
+		qw422016.N().D(item.Rank)
+		//line contributors/leaderboard.qtpl:77
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:78
+		qw422016.E().S(item.UserName)
+		//line contributors/leaderboard.qtpl:78
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:79
+		qw422016.N().D(item.Submitted)
+		//line contributors/leaderboard.qtpl:79
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:80
+		qw422016.N().D(item.Approved)
+		//line contributors/leaderboard.qtpl:80
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:81
+		qw422016.N().D(item.Rejected)
+		//line contributors/leaderboard.qtpl:81
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:82
+		qw422016.E().S(item.ApprovalRate.Shift(2).StringFixed(1))
+		//line contributors/leaderboard.qtpl:82
+		qw422016.N().S(`%</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:83
+		qw422016.E().S(item.Rewards.String())
+		//line contributors/leaderboard.qtpl:83
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:84
+		qw422016.N().D(item.CurrentStreak)
+		//line contributors/leaderboard.qtpl:84
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:85
+		qw422016.N().D(item.LongestStreak)
+		//line contributors/leaderboard.qtpl:85
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:86
+		if item.OptedOut {
+			//line contributors/leaderboard.qtpl:86
+			qw422016.N().S(`скрыт из публичного рейтинга`)
+		//line contributors/leaderboard.qtpl:86
+		}
+		//line contributors/leaderboard.qtpl:86
+		qw422016.N().S(`</td>
+	    </tr>
+	`)
+	//line contributors/leaderboard.qtpl:88
+	}
+	//line contributors/leaderboard.qtpl:88
+	qw422016.N().S(`
+	    </tbody>
+	</table>
+
+    <p>Данные на `)
+	//line contributors/leaderboard.qtpl:92
+	qw422016.E().S(p.Board.GeneratedAt.Format("2006-01-02 15:04"))
+	//line contributors/leaderboard.qtpl:92
+	qw422016.N().S(` UTC</p>
+`)
+//line contributors/leaderboard.qtpl:93
+}
+
+//line contributors/leaderboard.qtpl:93
+func (p *LeaderboardPage) WriteBody(qq422016 qtio422016.Writer) {
+	//line contributors/leaderboard.qtpl:93
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/leaderboard.qtpl:93
+	p.StreamBody(qw422016)
+	//line contributors/leaderboard.qtpl:93
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/leaderboard.qtpl:93
+}
+
+//line contributors/leaderboard.qtpl:93
+func (p *LeaderboardPage) Body() string {
+	//line contributors/leaderboard.qtpl:93
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/leaderboard.qtpl:93
+	p.WriteBody(qb422016)
+	//line contributors/leaderboard.qtpl:93
+	qs422016 := string(qb422016.B)
+	//line contributors/leaderboard.qtpl:93
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/leaderboard.qtpl:93
+	return qs422016
+//line contributors/leaderboard.qtpl:93
+}
+
+//line contributors/leaderboard.qtpl:95
+func (p *MissionStatsPage) StreamTitle(qw422016 *qt422016.Writer) {
+	//line contributors/leaderboard.qtpl:95
+	qw422016.N().S(`
+	Статистика миссий
+`)
+//line contributors/leaderboard.qtpl:97
+}
+
+//line contributors/leaderboard.qtpl:97
+func (p *MissionStatsPage) WriteTitle(qq422016 qtio422016.Writer) {
+	//line contributors/leaderboard.qtpl:97
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/leaderboard.qtpl:97
+	p.StreamTitle(qw422016)
+	//line contributors/leaderboard.qtpl:97
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/leaderboard.qtpl:97
+}
+
+//line contributors/leaderboard.qtpl:97
+func (p *MissionStatsPage) Title() string {
+	//line contributors/leaderboard.qtpl:97
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/leaderboard.qtpl:97
+	p.WriteTitle(qb422016)
+	//line contributors/leaderboard.qtpl:97
+	qs422016 := string(qb422016.B)
+	//line contributors/leaderboard.qtpl:97
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/leaderboard.qtpl:97
+	return qs422016
+//line contributors/leaderboard.qtpl:97
+}
+
+//line contributors/leaderboard.qtpl:99
+func (p *MissionStatsPage) StreamBody(qw422016 *qt422016.Writer) {
+	//line contributors/leaderboard.qtpl:99
+	qw422016.N().S(`
+
+    <h2>Статистика миссий</h2>
+
+    `)
+	//line contributors/leaderboard.qtpl:103
+	streamstatsMenu(qw422016, "/admin/stats/missions", p.Report.Period, p.Periods)
+	//line contributors/leaderboard.qtpl:103
+	qw422016.N().S(`
+
+	<table>
+	    <thead>
+	        <tr>
+	            <th>ID</th>
+	            <th>Миссия</th>
+	            <th>Участников</th>
+	            <th>Заявок</th>
+	            <th>Одобрено</th>
+	            <th>Отклонено</th>
+	            <th>Доля одобренных</th>
+	            <th>Награды</th>
+	        </tr>
+	    </thead>
+	    <tbody>
+	`)
+	//line contributors/leaderboard.qtpl:119
+	for _, item := range p.Report.Missions {
+		//line contributors/leaderboard.qtpl:119
+		qw422016.N().S(`
+	    <tr>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:121
+		qw422016.N().DL(item.MissionID)
+		//line contributors/leaderboard.qtpl:121
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:122
+		qw422016.E().S(item.Mission)
+		//line contributors/leaderboard.qtpl:122
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:123
+		qw422016.N().D(item.Contributors)
+		//line contributors/leaderboard.qtpl:123
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:124
+		qw422016.N().D(item.Submitted)
+		//line contributors/leaderboard.qtpl:124
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:125
+		qw422016.N().D(item.Approved)
+		//line contributors/leaderboard.qtpl:125
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:126
+		qw422016.N().D(item.Rejected)
+		//line contributors/leaderboard.qtpl:126
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:127
+		qw422016.E().S(item.ApprovalRate.Shift(2).StringFixed(1))
+		//line contributors/leaderboard.qtpl:127
+		qw422016.N().S(`%</td>
+	        <td>`)
+		//line contributors/leaderboard.qtpl:128
+		qw422016.E().S(item.Rewards.String())
+		//line contributors/leaderboard.qtpl:128
+		qw422016.N().S(`</td>
+	    </tr>
+	`)
+	//line contributors/leaderboard.qtpl:130
+	}
+	//line contributors/leaderboard.qtpl:130
+	qw422016.N().S(`
+	    </tbody>
+	</table>
+
+    <p>Данные на `)
+	//line contributors/leaderboard.qtpl:134
+	qw422016.E().S(p.Report.GeneratedAt.Format("2006-01-02 15:04"))
+	//line contributors/leaderboard.qtpl:134
+	qw422016.N().S(` UTC</p>
+`)
+//line contributors/leaderboard.qtpl:135
+}
+
+//line contributors/leaderboard.qtpl:135
+func (p *MissionStatsPage) WriteBody(qq422016 qtio422016.Writer) {
+	//line contributors/leaderboard.qtpl:135
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/leaderboard.qtpl:135
+	p.StreamBody(qw422016)
+	//line contributors/leaderboard.qtpl:135
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/leaderboard.qtpl:135
+}
+
+//line contributors/leaderboard.qtpl:135
+func (p *MissionStatsPage) Body() string {
+	//line contributors/leaderboard.qtpl:135
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/leaderboard.qtpl:135
+	p.WriteBody(qb422016)
+	//line contributors/leaderboard.qtpl:135
+	qs422016 := string(qb422016.B)
+	//line contributors/leaderboard.qtpl:135
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/leaderboard.qtpl:135
+	return qs422016
+//line contributors/leaderboard.qtpl:135
+}
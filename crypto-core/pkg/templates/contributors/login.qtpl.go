@@ -0,0 +1,132 @@
+// This file is automatically generated by qtc from "login.qtpl".
+// See https://github.com/valyala/quicktemplate for details.
+
+// Login page template. Implements Page methods.
+//
+
+//line contributors/login.qtpl:3
+package contributors
+
+//line contributors/login.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line contributors/login.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line contributors/login.qtpl:4
+type LoginPage struct {
+	BasePage
+	Email string
+	Error string
+}
+
+//line contributors/login.qtpl:11
+func (p *LoginPage) StreamTitle(qw422016 *qt422016.Writer) {
+	//line contributors/login.qtpl:11
+	qw422016.N().S(`
+	Вход
+`)
+//line contributors/login.qtpl:13
+}
+
+//line contributors/login.qtpl:13
+func (p *LoginPage) WriteTitle(qq422016 qtio422016.Writer) {
+	//line contributors/login.qtpl:13
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/login.qtpl:13
+	p.StreamTitle(qw422016)
+	//line contributors/login.qtpl:13
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/login.qtpl:13
+}
+
+//line contributors/login.qtpl:13
+func (p *LoginPage) Title() string {
+	//line contributors/login.qtpl:13
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/login.qtpl:13
+	p.WriteTitle(qb422016)
+	//line contributors/login.qtpl:13
+	qs422016 := string(qb422016.B)
+	//line contributors/login.qtpl:13
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/login.qtpl:13
+	return qs422016
+//line contributors/login.qtpl:13
+}
+
+//line contributors/login.qtpl:15
+func (p *LoginPage) StreamBody(qw422016 *qt422016.Writer) {
+	//line contributors/login.qtpl:15
+	qw422016.N().S(`
+
+    <h2>Вход</h2>
+
+    `)
+	//line contributors/login.qtpl:19
+	if p.Error != "" {
+		//line contributors/login.qtpl:19
+		qw422016.N().S(`
+    <p><b>`)
+		//line contributors/login.qtpl:20
+		qw422016.E().S(p.Error)
+		//line contributors/login.qtpl:20
+		qw422016.N().S(`</b></p>
+    `)
+	//line contributors/login.qtpl:21
+	}
+	//line contributors/login.qtpl:21
+	qw422016.N().S(`
+
+    <form method="post" action="/admin/login">
+        `)
+	//line contributors/login.qtpl:24
+	p.StreamCSRFField(qw422016)
+	//line contributors/login.qtpl:24
+	qw422016.N().S(`
+        <label for="email">Email</label>
+        <input type="email" id="email" name="email" value="`)
+	//line contributors/login.qtpl:26
+	qw422016.E().S(p.Email)
+	//line contributors/login.qtpl:26
+	qw422016.N().S(`" required>
+        <label for="password">Пароль</label>
+        <input type="password" id="password" name="password" required>
+        <button type="submit">Войти</button>
+    </form>
+`)
+//line contributors/login.qtpl:31
+}
+
+//line contributors/login.qtpl:31
+func (p *LoginPage) WriteBody(qq422016 qtio422016.Writer) {
+	//line contributors/login.qtpl:31
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/login.qtpl:31
+	p.StreamBody(qw422016)
+	//line contributors/login.qtpl:31
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/login.qtpl:31
+}
+
+//line contributors/login.qtpl:31
+func (p *LoginPage) Body() string {
+	//line contributors/login.qtpl:31
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/login.qtpl:31
+	p.WriteBody(qb422016)
+	//line contributors/login.qtpl:31
+	qs422016 := string(qb422016.B)
+	//line contributors/login.qtpl:31
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/login.qtpl:31
+	return qs422016
+//line contributors/login.qtpl:31
+}
@@ -0,0 +1,220 @@
+// This file is automatically generated by qtc from "missionForm.qtpl".
+// See https://github.com/valyala/quicktemplate for details.
+
+// Mission create and edit form template. Implements Page methods.
+//
+
+//line contributors/missionForm.qtpl:3
+package contributors
+
+//line contributors/missionForm.qtpl:3
+import (
+	contributor "github.com/bfg-dev/crypto-core/pkg/services/contributor"
+)
+
+//line contributors/missionForm.qtpl:8
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line contributors/missionForm.qtpl:8
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+// MissionForm keeps raw form values to show them back on validation error
+//
+//line contributors/missionForm.qtpl:9
+type MissionForm struct {
+	Title       string
+	Description string
+	Reward      string
+	// ParameterSchema is listed one "name:type" or "name:type:optional" line per parameter
+	ParameterSchema string
+	ActiveFrom      string
+	ActiveTo        string
+}
+
+type MissionFormPage struct {
+	BasePage
+	// MissionID is empty for a new mission
+	MissionID int64
+	Form      MissionForm
+	Error     string
+}
+
+//line contributors/missionForm.qtpl:29
+func (p *MissionFormPage) StreamTitle(qw422016 *qt422016.Writer) {
+	//line contributors/missionForm.qtpl:29
+	qw422016.N().S(`
+	`)
+	//line contributors/missionForm.qtpl:30
+	if p.MissionID == 0 {
+		//line contributors/missionForm.qtpl:30
+		qw422016.N().S(`Новая миссия`)
+	//line contributors/missionForm.qtpl:30
+	} else {
+		//line contributors/missionForm.qtpl:30
+		qw422016.N().S(`Миссия #`)
+		//line contributors/missionForm.qtpl:30
+		qw422016.N().DL(p.MissionID)
+	//line contributors/missionForm.qtpl:30
+	}
+	//line contributors/missionForm.qtpl:30
+	qw422016.N().S(`
+`)
+//line contributors/missionForm.qtpl:31
+}
+
+//line contributors/missionForm.qtpl:31
+func (p *MissionFormPage) WriteTitle(qq422016 qtio422016.Writer) {
+	//line contributors/missionForm.qtpl:31
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/missionForm.qtpl:31
+	p.StreamTitle(qw422016)
+	//line contributors/missionForm.qtpl:31
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/missionForm.qtpl:31
+}
+
+//line contributors/missionForm.qtpl:31
+func (p *MissionFormPage) Title() string {
+	//line contributors/missionForm.qtpl:31
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/missionForm.qtpl:31
+	p.WriteTitle(qb422016)
+	//line contributors/missionForm.qtpl:31
+	qs422016 := string(qb422016.B)
+	//line contributors/missionForm.qtpl:31
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/missionForm.qtpl:31
+	return qs422016
+//line contributors/missionForm.qtpl:31
+}
+
+//line contributors/missionForm.qtpl:33
+func (p *MissionFormPage) StreamBody(qw422016 *qt422016.Writer) {
+	//line contributors/missionForm.qtpl:33
+	qw422016.N().S(`
+
+    <h2>`)
+	//line contributors/missionForm.qtpl:35
+	p.StreamTitle(qw422016)
+	//line contributors/missionForm.qtpl:35
+	qw422016.N().S(`</h2>
+
+    `)
+	//line contributors/missionForm.qtpl:37
+	if p.Error != "" {
+		//line contributors/missionForm.qtpl:37
+		qw422016.N().S(`
+        <p>`)
+		//line contributors/missionForm.qtpl:38
+		qw422016.E().S(p.Error)
+		//line contributors/missionForm.qtpl:38
+		qw422016.N().S(`</p>
+    `)
+	//line contributors/missionForm.qtpl:39
+	}
+	//line contributors/missionForm.qtpl:39
+	qw422016.N().S(`
+
+    <form method="post" action="/admin/missions`)
+	//line contributors/missionForm.qtpl:41
+	if p.MissionID != 0 {
+		//line contributors/missionForm.qtpl:41
+		qw422016.N().S(`/`)
+		//line contributors/missionForm.qtpl:41
+		qw422016.N().DL(p.MissionID)
+	//line contributors/missionForm.qtpl:41
+	}
+	//line contributors/missionForm.qtpl:41
+	qw422016.N().S(`">
+        `)
+	//line contributors/missionForm.qtpl:42
+	p.StreamCSRFField(qw422016)
+	//line contributors/missionForm.qtpl:42
+	qw422016.N().S(`
+        <p><label>Название<br><input type="text" name="title" value="`)
+	//line contributors/missionForm.qtpl:43
+	qw422016.E().S(p.Form.Title)
+	//line contributors/missionForm.qtpl:43
+	qw422016.N().S(`"></label></p>
+        <p><label>Описание<br><textarea name="description">`)
+	//line contributors/missionForm.qtpl:44
+	qw422016.E().S(p.Form.Description)
+	//line contributors/missionForm.qtpl:44
+	qw422016.N().S(`</textarea></label></p>
+        <p><label>Награда<br><input type="text" name="reward" value="`)
+	//line contributors/missionForm.qtpl:45
+	qw422016.E().S(p.Form.Reward)
+	//line contributors/missionForm.qtpl:45
+	qw422016.N().S(`"></label></p>
+        <p><label>Параметры, по одному в строке в формате «имя:тип» или «имя:тип:optional» для необязательных<br><textarea name="parameterSchema">`)
+	//line contributors/missionForm.qtpl:46
+	qw422016.E().S(p.Form.ParameterSchema)
+	//line contributors/missionForm.qtpl:46
+	qw422016.N().S(`</textarea></label></p>
+        <p>Типы:
+        `)
+	//line contributors/missionForm.qtpl:48
+	for i, t := range contributor.ParameterTypes {
+		//line contributors/missionForm.qtpl:48
+		if i > 0 {
+			//line contributors/missionForm.qtpl:48
+			qw422016.N().S(`, `)
+		//line contributors/missionForm.qtpl:48
+		}
+		//line contributors/missionForm.qtpl:48
+		qw422016.E().S(string(t))
+	//line contributors/missionForm.qtpl:48
+	}
+	//line contributors/missionForm.qtpl:48
+	qw422016.N().S(`
+        </p>
+        <p><label>Активна с<br><input type="date" name="activeFrom" value="`)
+	//line contributors/missionForm.qtpl:50
+	qw422016.E().S(p.Form.ActiveFrom)
+	//line contributors/missionForm.qtpl:50
+	qw422016.N().S(`"></label></p>
+        <p><label>Активна до (не включая)<br><input type="date" name="activeTo" value="`)
+	//line contributors/missionForm.qtpl:51
+	qw422016.E().S(p.Form.ActiveTo)
+	//line contributors/missionForm.qtpl:51
+	qw422016.N().S(`"></label></p>
+        <button type="submit">Сохранить</button>
+    </form>
+
+    <p><a href="/admin/missions">Вернуться к списку миссий</a></p>
+`)
+//line contributors/missionForm.qtpl:56
+}
+
+//line contributors/missionForm.qtpl:56
+func (p *MissionFormPage) WriteBody(qq422016 qtio422016.Writer) {
+	//line contributors/missionForm.qtpl:56
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/missionForm.qtpl:56
+	p.StreamBody(qw422016)
+	//line contributors/missionForm.qtpl:56
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/missionForm.qtpl:56
+}
+
+//line contributors/missionForm.qtpl:56
+func (p *MissionFormPage) Body() string {
+	//line contributors/missionForm.qtpl:56
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/missionForm.qtpl:56
+	p.WriteBody(qb422016)
+	//line contributors/missionForm.qtpl:56
+	qs422016 := string(qb422016.B)
+	//line contributors/missionForm.qtpl:56
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/missionForm.qtpl:56
+	return qs422016
+//line contributors/missionForm.qtpl:56
+}
@@ -0,0 +1,254 @@
+// This file is automatically generated by qtc from "missionsList.qtpl".
+// See https://github.com/valyala/quicktemplate for details.
+
+// Missions catalogue page template. Implements Page methods.
+//
+
+//line contributors/missionsList.qtpl:3
+package contributors
+
+//line contributors/missionsList.qtpl:3
+import (
+	timeformat "github.com/bfg-dev/crypto-core/pkg/helpers/timeformat"
+	contributor "github.com/bfg-dev/crypto-core/pkg/services/contributor"
+)
+
+//line contributors/missionsList.qtpl:9
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line contributors/missionsList.qtpl:9
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line contributors/missionsList.qtpl:10
+type MissionsListPage struct {
+	BasePage
+	Missions        []contributor.Mission
+	IncludeArchived bool
+}
+
+//line contributors/missionsList.qtpl:17
+func (p *MissionsListPage) StreamTitle(qw422016 *qt422016.Writer) {
+	//line contributors/missionsList.qtpl:17
+	qw422016.N().S(`
+	Миссии
+`)
+//line contributors/missionsList.qtpl:19
+}
+
+//line contributors/missionsList.qtpl:19
+func (p *MissionsListPage) WriteTitle(qq422016 qtio422016.Writer) {
+	//line contributors/missionsList.qtpl:19
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/missionsList.qtpl:19
+	p.StreamTitle(qw422016)
+	//line contributors/missionsList.qtpl:19
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/missionsList.qtpl:19
+}
+
+//line contributors/missionsList.qtpl:19
+func (p *MissionsListPage) Title() string {
+	//line contributors/missionsList.qtpl:19
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/missionsList.qtpl:19
+	p.WriteTitle(qb422016)
+	//line contributors/missionsList.qtpl:19
+	qs422016 := string(qb422016.B)
+	//line contributors/missionsList.qtpl:19
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/missionsList.qtpl:19
+	return qs422016
+//line contributors/missionsList.qtpl:19
+}
+
+//line contributors/missionsList.qtpl:21
+func (p *MissionsListPage) StreamBody(qw422016 *qt422016.Writer) {
+	//line contributors/missionsList.qtpl:21
+	qw422016.N().S(`
+
+    <h2>Миссии</h2>
+
+    <p>
+        <a href="/admin/missions/new">Добавить миссию</a>
+        `)
+	//line contributors/missionsList.qtpl:27
+	if p.IncludeArchived {
+		//line contributors/missionsList.qtpl:27
+		qw422016.N().S(`
+            <a href="/admin/missions">Скрыть архивные</a>
+        `)
+	//line contributors/missionsList.qtpl:29
+	} else {
+		//line contributors/missionsList.qtpl:29
+		qw422016.N().S(`
+            <a href="/admin/missions?archived=true">Показать архивные</a>
+        `)
+	//line contributors/missionsList.qtpl:31
+	}
+	//line contributors/missionsList.qtpl:31
+	qw422016.N().S(`
+        <a href="/admin/NewUserMissionRequests">Запросы на миссии</a>
+    </p>
+
+	<table>
+	    <thead>
+	        <tr>
+	            <th>ID</th>
+	            <th>Название</th>
+	            <th>Награда</th>
+	            <th>Параметры</th>
+	            <th>Активна с</th>
+	            <th>Активна до</th>
+	            <th>&nbsp;</th>
+	        </tr>
+	    </thead>
+	    <tbody>
+	`)
+	//line contributors/missionsList.qtpl:48
+	for _, mission := range p.Missions {
+		//line contributors/missionsList.qtpl:48
+		qw422016.N().S(`
+	    <tr>
+	        <td>`)
+		//line contributors/missionsList.qtpl:50
+		qw422016.N().DL(mission.ID)
+		//line contributors/missionsList.qtpl:50
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/missionsList.qtpl:51
+		qw422016.E().S(mission.Title)
+		//line contributors/missionsList.qtpl:51
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/missionsList.qtpl:52
+		qw422016.E().S(mission.Reward.String())
+		//line contributors/missionsList.qtpl:52
+		qw422016.N().S(`</td>
+	        <td>
+	        `)
+		//line contributors/missionsList.qtpl:54
+		for _, spec := range mission.ParameterSchema {
+			//line contributors/missionsList.qtpl:54
+			qw422016.N().S(`
+	            `)
+			//line contributors/missionsList.qtpl:55
+			qw422016.E().S(spec.Name)
+			//line contributors/missionsList.qtpl:55
+			qw422016.N().S(` (`)
+			//line contributors/missionsList.qtpl:55
+			qw422016.E().S(string(spec.Type))
+			//line contributors/missionsList.qtpl:55
+			if !spec.Required {
+				//line contributors/missionsList.qtpl:55
+				qw422016.N().S(`, необязательный`)
+			//line contributors/missionsList.qtpl:55
+			}
+			//line contributors/missionsList.qtpl:55
+			qw422016.N().S(`)<br>
+	        `)
+		//line contributors/missionsList.qtpl:56
+		}
+		//line contributors/missionsList.qtpl:56
+		qw422016.N().S(`
+	        </td>
+	        <td>`)
+		//line contributors/missionsList.qtpl:58
+		if mission.ActiveFrom != nil {
+			//line contributors/missionsList.qtpl:58
+			qw422016.E().S(mission.ActiveFrom.Format(timeformat.Date))
+		//line contributors/missionsList.qtpl:58
+		}
+		//line contributors/missionsList.qtpl:58
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/missionsList.qtpl:59
+		if mission.ActiveTo != nil {
+			//line contributors/missionsList.qtpl:59
+			qw422016.E().S(mission.ActiveTo.Format(timeformat.Date))
+		//line contributors/missionsList.qtpl:59
+		}
+		//line contributors/missionsList.qtpl:59
+		qw422016.N().S(`</td>
+	        <td>
+	        `)
+		//line contributors/missionsList.qtpl:61
+		if mission.ArchivedAt != nil {
+			//line contributors/missionsList.qtpl:61
+			qw422016.N().S(`
+	            В архиве с `)
+			//line contributors/missionsList.qtpl:62
+			qw422016.E().S(mission.ArchivedAt.Format(timeformat.Date))
+			//line contributors/missionsList.qtpl:62
+			qw422016.N().S(`
+	        `)
+		//line contributors/missionsList.qtpl:63
+		} else {
+			//line contributors/missionsList.qtpl:63
+			qw422016.N().S(`
+	            <a href="/admin/missions/`)
+			//line contributors/missionsList.qtpl:64
+			qw422016.N().DL(mission.ID)
+			//line contributors/missionsList.qtpl:64
+			qw422016.N().S(`/edit">Изменить</a>
+	            <form method="post" action="/admin/missions/`)
+			//line contributors/missionsList.qtpl:65
+			qw422016.N().DL(mission.ID)
+			//line contributors/missionsList.qtpl:65
+			qw422016.N().S(`/archive">
+	                `)
+			//line contributors/missionsList.qtpl:66
+			p.StreamCSRFField(qw422016)
+			//line contributors/missionsList.qtpl:66
+			qw422016.N().S(`
+	                <button type="submit">В архив</button>
+	            </form>
+	        `)
+		//line contributors/missionsList.qtpl:69
+		}
+		//line contributors/missionsList.qtpl:69
+		qw422016.N().S(`
+	        </td>
+	    </tr>
+	`)
+	//line contributors/missionsList.qtpl:72
+	}
+	//line contributors/missionsList.qtpl:72
+	qw422016.N().S(`
+	    </tbody>
+	</table>
+`)
+//line contributors/missionsList.qtpl:75
+}
+
+//line contributors/missionsList.qtpl:75
+func (p *MissionsListPage) WriteBody(qq422016 qtio422016.Writer) {
+	//line contributors/missionsList.qtpl:75
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/missionsList.qtpl:75
+	p.StreamBody(qw422016)
+	//line contributors/missionsList.qtpl:75
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/missionsList.qtpl:75
+}
+
+//line contributors/missionsList.qtpl:75
+func (p *MissionsListPage) Body() string {
+	//line contributors/missionsList.qtpl:75
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/missionsList.qtpl:75
+	p.WriteBody(qb422016)
+	//line contributors/missionsList.qtpl:75
+	qs422016 := string(qb422016.B)
+	//line contributors/missionsList.qtpl:75
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/missionsList.qtpl:75
+	return qs422016
+//line contributors/missionsList.qtpl:75
+}
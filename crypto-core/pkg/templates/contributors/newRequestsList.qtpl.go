@@ -9,168 +9,559 @@ package contributors
 
 //line contributors/newRequestsList.qtpl:3
 import (
+	"net/url"
+
 	timeformat "github.com/bfg-dev/crypto-core/pkg/helpers/timeformat"
 	contributor "github.com/bfg-dev/crypto-core/pkg/services/contributor"
 )
 
-//line contributors/newRequestsList.qtpl:9
+//line contributors/newRequestsList.qtpl:11
 import (
 	qtio422016 "io"
 
 	qt422016 "github.com/valyala/quicktemplate"
 )
 
-//line contributors/newRequestsList.qtpl:9
+//line contributors/newRequestsList.qtpl:11
 var (
 	_ = qtio422016.Copy
 	_ = qt422016.AcquireByteBuffer
 )
 
-//line contributors/newRequestsList.qtpl:10
+//line contributors/newRequestsList.qtpl:12
 type NewRequestsListPage struct {
+	BasePage
 	Requests []contributor.UserMissionRequest
+	Reasons  []contributor.RejectionReason
+	// Query keeps list filter to fill filter inputs and build pagination links
+	Query      url.Values
+	NextCursor string
+	Error      string
+}
+
+var listStatuses = []struct {
+	Value string
+	Title string
+}{
+	{"new", "Новые"},
+	{"approved", "Одобренные"},
+	{"rejected", "Отклонённые"},
+	{"paid", "Выплаченные"},
+	{"withdrawn", "Отозванные"},
+	{"any", "Все"},
+}
+
+//line contributors/newRequestsList.qtpl:36
+func (p *NewRequestsListPage) status() string {
+	if status, ok := p.Query["status"]; ok {
+		return status[0]
+	}
+	return string(contributor.StatusNew)
 }
 
-//line contributors/newRequestsList.qtpl:15
+func (p *NewRequestsListPage) pageURL(cursor string) string {
+	query := url.Values{}
+	for key, values := range p.Query {
+		query[key] = values
+	}
+	query.Del("cursor")
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	return "/admin/NewUserMissionRequests?" + query.Encode()
+}
+
+// missionParameter prints parameter value according to its type, invalid urls are printed as text.
+
+//line contributors/newRequestsList.qtpl:57
+func streammissionParameter(qw422016 *qt422016.Writer, param contributor.MissionParameter) {
+	//line contributors/newRequestsList.qtpl:57
+	qw422016.N().S(`
+    `)
+	//line contributors/newRequestsList.qtpl:58
+	switch {
+	//line contributors/newRequestsList.qtpl:59
+	case param.IsLink():
+		//line contributors/newRequestsList.qtpl:59
+		qw422016.N().S(`
+        <a href="`)
+		//line contributors/newRequestsList.qtpl:60
+		qw422016.E().S(param.Value)
+		//line contributors/newRequestsList.qtpl:60
+		qw422016.N().S(`" target="_blank" rel="noopener noreferrer">`)
+		//line contributors/newRequestsList.qtpl:60
+		qw422016.E().S(param.Value)
+		//line contributors/newRequestsList.qtpl:60
+		qw422016.N().S(`</a>
+    `)
+	//line contributors/newRequestsList.qtpl:61
+	case param.Type == contributor.ParameterEmail:
+		//line contributors/newRequestsList.qtpl:61
+		qw422016.N().S(`
+        <a href="mailto:`)
+		//line contributors/newRequestsList.qtpl:62
+		qw422016.E().S(param.Value)
+		//line contributors/newRequestsList.qtpl:62
+		qw422016.N().S(`">`)
+		//line contributors/newRequestsList.qtpl:62
+		qw422016.E().S(param.Value)
+		//line contributors/newRequestsList.qtpl:62
+		qw422016.N().S(`</a>
+    `)
+	//line contributors/newRequestsList.qtpl:63
+	case param.Type == contributor.ParameterWallet:
+		//line contributors/newRequestsList.qtpl:63
+		qw422016.N().S(`
+        <code>`)
+		//line contributors/newRequestsList.qtpl:64
+		qw422016.E().S(param.Value)
+		//line contributors/newRequestsList.qtpl:64
+		qw422016.N().S(`</code>
+    `)
+	//line contributors/newRequestsList.qtpl:65
+	default:
+		//line contributors/newRequestsList.qtpl:65
+		qw422016.N().S(`
+        `)
+		//line contributors/newRequestsList.qtpl:66
+		qw422016.E().S(param.Value)
+		//line contributors/newRequestsList.qtpl:66
+		qw422016.N().S(`
+    `)
+	//line contributors/newRequestsList.qtpl:67
+	}
+	//line contributors/newRequestsList.qtpl:67
+	qw422016.N().S(`
+`)
+//line contributors/newRequestsList.qtpl:68
+}
+
+//line contributors/newRequestsList.qtpl:68
+func writemissionParameter(qq422016 qtio422016.Writer, param contributor.MissionParameter) {
+	//line contributors/newRequestsList.qtpl:68
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/newRequestsList.qtpl:68
+	streammissionParameter(qw422016, param)
+	//line contributors/newRequestsList.qtpl:68
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/newRequestsList.qtpl:68
+}
+
+//line contributors/newRequestsList.qtpl:68
+func missionParameter(param contributor.MissionParameter) string {
+	//line contributors/newRequestsList.qtpl:68
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/newRequestsList.qtpl:68
+	writemissionParameter(qb422016, param)
+	//line contributors/newRequestsList.qtpl:68
+	qs422016 := string(qb422016.B)
+	//line contributors/newRequestsList.qtpl:68
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/newRequestsList.qtpl:68
+	return qs422016
+//line contributors/newRequestsList.qtpl:68
+}
+
+//line contributors/newRequestsList.qtpl:70
 func (p *NewRequestsListPage) StreamTitle(qw422016 *qt422016.Writer) {
-	//line contributors/newRequestsList.qtpl:15
+	//line contributors/newRequestsList.qtpl:70
 	qw422016.N().S(`
 	This is table page
 `)
-//line contributors/newRequestsList.qtpl:17
+//line contributors/newRequestsList.qtpl:72
 }
 
-//line contributors/newRequestsList.qtpl:17
+//line contributors/newRequestsList.qtpl:72
 func (p *NewRequestsListPage) WriteTitle(qq422016 qtio422016.Writer) {
-	//line contributors/newRequestsList.qtpl:17
+	//line contributors/newRequestsList.qtpl:72
 	qw422016 := qt422016.AcquireWriter(qq422016)
-	//line contributors/newRequestsList.qtpl:17
+	//line contributors/newRequestsList.qtpl:72
 	p.StreamTitle(qw422016)
-	//line contributors/newRequestsList.qtpl:17
+	//line contributors/newRequestsList.qtpl:72
 	qt422016.ReleaseWriter(qw422016)
-//line contributors/newRequestsList.qtpl:17
+//line contributors/newRequestsList.qtpl:72
 }
 
-//line contributors/newRequestsList.qtpl:17
+//line contributors/newRequestsList.qtpl:72
 func (p *NewRequestsListPage) Title() string {
-	//line contributors/newRequestsList.qtpl:17
+	//line contributors/newRequestsList.qtpl:72
 	qb422016 := qt422016.AcquireByteBuffer()
-	//line contributors/newRequestsList.qtpl:17
+	//line contributors/newRequestsList.qtpl:72
 	p.WriteTitle(qb422016)
-	//line contributors/newRequestsList.qtpl:17
+	//line contributors/newRequestsList.qtpl:72
 	qs422016 := string(qb422016.B)
-	//line contributors/newRequestsList.qtpl:17
+	//line contributors/newRequestsList.qtpl:72
 	qt422016.ReleaseByteBuffer(qb422016)
-	//line contributors/newRequestsList.qtpl:17
+	//line contributors/newRequestsList.qtpl:72
 	return qs422016
-//line contributors/newRequestsList.qtpl:17
+//line contributors/newRequestsList.qtpl:72
 }
 
-//line contributors/newRequestsList.qtpl:19
+//line contributors/newRequestsList.qtpl:74
 func (p *NewRequestsListPage) StreamBody(qw422016 *qt422016.Writer) {
-	//line contributors/newRequestsList.qtpl:19
+	//line contributors/newRequestsList.qtpl:74
+	qw422016.N().S(`
+
+    <form method="post" action="/admin/logout">
+        `)
+	//line contributors/newRequestsList.qtpl:77
+	p.StreamCSRFField(qw422016)
+	//line contributors/newRequestsList.qtpl:77
+	qw422016.N().S(`
+        <button type="submit">Выйти</button>
+    </form>
+
+    <p><a href="/admin/missions">Миссии</a> <a href="/admin/payouts">Выплаты</a> <a href="/admin/stats/leaderboard">Статистика</a></p>
+
+    <h2>Список запросов на миссии</h2>
+
+    <form method="get" action="/admin/NewUserMissionRequests">
+        <select name="status">
+        `)
+	//line contributors/newRequestsList.qtpl:87
+	for _, status := range listStatuses {
+		//line contributors/newRequestsList.qtpl:87
+		qw422016.N().S(`
+            <option value="`)
+		//line contributors/newRequestsList.qtpl:88
+		qw422016.E().S(status.Value)
+		//line contributors/newRequestsList.qtpl:88
+		qw422016.N().S(`"`)
+		//line contributors/newRequestsList.qtpl:88
+		if status.Value == p.status() {
+			//line contributors/newRequestsList.qtpl:88
+			qw422016.N().S(` selected`)
+		//line contributors/newRequestsList.qtpl:88
+		}
+		//line contributors/newRequestsList.qtpl:88
+		qw422016.N().S(`>`)
+		//line contributors/newRequestsList.qtpl:88
+		qw422016.E().S(status.Title)
+		//line contributors/newRequestsList.qtpl:88
+		qw422016.N().S(`</option>
+        `)
+	//line contributors/newRequestsList.qtpl:89
+	}
+	//line contributors/newRequestsList.qtpl:89
 	qw422016.N().S(`
+        </select>
+        <input type="text" name="missionId" placeholder="ID миссии" value="`)
+	//line contributors/newRequestsList.qtpl:91
+	qw422016.E().S(p.Query.Get("missionId"))
+	//line contributors/newRequestsList.qtpl:91
+	qw422016.N().S(`">
+        <input type="text" name="userId" placeholder="ID пользователя" value="`)
+	//line contributors/newRequestsList.qtpl:92
+	qw422016.E().S(p.Query.Get("userId"))
+	//line contributors/newRequestsList.qtpl:92
+	qw422016.N().S(`">
+        <input type="date" name="from" value="`)
+	//line contributors/newRequestsList.qtpl:93
+	qw422016.E().S(p.Query.Get("from"))
+	//line contributors/newRequestsList.qtpl:93
+	qw422016.N().S(`">
+        <input type="date" name="to" value="`)
+	//line contributors/newRequestsList.qtpl:94
+	qw422016.E().S(p.Query.Get("to"))
+	//line contributors/newRequestsList.qtpl:94
+	qw422016.N().S(`">
+        <select name="sort">
+            <option value="`)
+	//line contributors/newRequestsList.qtpl:96
+	qw422016.E().S(string(contributor.SortCreatedAtDesc))
+	//line contributors/newRequestsList.qtpl:96
+	qw422016.N().S(`">Сначала новые</option>
+            <option value="`)
+	//line contributors/newRequestsList.qtpl:97
+	qw422016.E().S(string(contributor.SortCreatedAtAsc))
+	//line contributors/newRequestsList.qtpl:97
+	qw422016.N().S(`"`)
+	//line contributors/newRequestsList.qtpl:97
+	if p.Query.Get("sort") == string(contributor.SortCreatedAtAsc) {
+		//line contributors/newRequestsList.qtpl:97
+		qw422016.N().S(` selected`)
+	//line contributors/newRequestsList.qtpl:97
+	}
+	//line contributors/newRequestsList.qtpl:97
+	qw422016.N().S(`>Сначала старые</option>
+        </select>
+        <button type="submit">Показать</button>
+    </form>
 
-    <h2>Список новых запросов на миссии</h2>
+    `)
+	//line contributors/newRequestsList.qtpl:102
+	if p.Error != "" {
+		//line contributors/newRequestsList.qtpl:102
+		qw422016.N().S(`
+        <p>`)
+		//line contributors/newRequestsList.qtpl:103
+		qw422016.E().S(p.Error)
+		//line contributors/newRequestsList.qtpl:103
+		qw422016.N().S(`</p>
+    `)
+	//line contributors/newRequestsList.qtpl:104
+	}
+	//line contributors/newRequestsList.qtpl:104
+	qw422016.N().S(`
+
+    <form id="bulk" method="post" action="/admin/BulkSetUserRequestStatus">
+        `)
+	//line contributors/newRequestsList.qtpl:107
+	p.StreamCSRFField(qw422016)
+	//line contributors/newRequestsList.qtpl:107
+	qw422016.N().S(`
+        <select name="reason">
+            <option value="">Причина отказа</option>
+        `)
+	//line contributors/newRequestsList.qtpl:110
+	for _, reason := range p.Reasons {
+		//line contributors/newRequestsList.qtpl:110
+		qw422016.N().S(`
+            <option value="`)
+		//line contributors/newRequestsList.qtpl:111
+		qw422016.E().S(reason.Code)
+		//line contributors/newRequestsList.qtpl:111
+		qw422016.N().S(`">`)
+		//line contributors/newRequestsList.qtpl:111
+		qw422016.E().S(reason.Title)
+		//line contributors/newRequestsList.qtpl:111
+		qw422016.N().S(`</option>
+        `)
+	//line contributors/newRequestsList.qtpl:112
+	}
+	//line contributors/newRequestsList.qtpl:112
+	qw422016.N().S(`
+        </select>
+        <input type="text" name="comment" placeholder="Комментарий">
+        <button type="submit" name="status" value="approved">Одобрить выбранные</button>
+        <button type="submit" name="status" value="rejected">Отклонить выбранные</button>
+    </form>
 
 	<table>
 	    <thead>
 	        <tr>
+	            <th>&nbsp;</th>
 	            <th>Дата</th>
 	            <th>Пользователь</th>
 	            <th>Миссия</th>
+	            <th>Статус</th>
 	            <th>Параметры запроса</th>
+	            <th>Причина отказа</th>
+	            <th>Комментарий модератора</th>
 	            <th>&nbsp;</th>
 	        </tr>
 	    </thead>
 	    <tbody>
 	`)
-	//line contributors/newRequestsList.qtpl:34
+	//line contributors/newRequestsList.qtpl:134
 	for _, request := range p.Requests {
-		//line contributors/newRequestsList.qtpl:34
+		//line contributors/newRequestsList.qtpl:134
 		qw422016.N().S(`
 	    <form method="post" action="/admin/SetUserRequestStatus">
+	    `)
+		//line contributors/newRequestsList.qtpl:136
+		p.StreamCSRFField(qw422016)
+		//line contributors/newRequestsList.qtpl:136
+		qw422016.N().S(`
 	    <input type="hidden" name="id" value="`)
-		//line contributors/newRequestsList.qtpl:36
+		//line contributors/newRequestsList.qtpl:137
 		qw422016.N().D(int(request.ID))
-		//line contributors/newRequestsList.qtpl:36
+		//line contributors/newRequestsList.qtpl:137
 		qw422016.N().S(`">
 	    <tr>
+	        <td><input type="checkbox" form="bulk" name="ids" value="`)
+		//line contributors/newRequestsList.qtpl:139
+		qw422016.N().DL(request.ID)
+		//line contributors/newRequestsList.qtpl:139
+		qw422016.N().S(`"></td>
 	        <td>`)
-		//line contributors/newRequestsList.qtpl:38
+		//line contributors/newRequestsList.qtpl:140
 		qw422016.E().S(request.CreatedAt.Format(timeformat.Date))
-		//line contributors/newRequestsList.qtpl:38
+		//line contributors/newRequestsList.qtpl:140
 		qw422016.N().S(`</td>
             <td>`)
-		//line contributors/newRequestsList.qtpl:39
+		//line contributors/newRequestsList.qtpl:141
 		qw422016.E().S(request.UserName)
-		//line contributors/newRequestsList.qtpl:39
+		//line contributors/newRequestsList.qtpl:141
 		qw422016.N().S(`</td>
             <td>`)
-		//line contributors/newRequestsList.qtpl:40
+		//line contributors/newRequestsList.qtpl:142
 		qw422016.E().S(request.Mission)
-		//line contributors/newRequestsList.qtpl:40
+		//line contributors/newRequestsList.qtpl:142
+		qw422016.N().S(`</td>
+            <td>`)
+		//line contributors/newRequestsList.qtpl:143
+		qw422016.E().S(string(request.Status))
+		//line contributors/newRequestsList.qtpl:143
 		qw422016.N().S(`</td>
             <td>
             `)
-		//line contributors/newRequestsList.qtpl:42
-		for key, param := range request.MissionParameters {
-			//line contributors/newRequestsList.qtpl:42
+		//line contributors/newRequestsList.qtpl:145
+		if request.ParametersError != "" {
+			//line contributors/newRequestsList.qtpl:145
+			qw422016.N().S(`
+                <b>Не удалось разобрать параметры:</b> `)
+			//line contributors/newRequestsList.qtpl:146
+			qw422016.E().S(request.ParametersError)
+			//line contributors/newRequestsList.qtpl:146
 			qw422016.N().S(`
-                `)
-			//line contributors/newRequestsList.qtpl:43
-			qw422016.E().S(key)
-			//line contributors/newRequestsList.qtpl:43
-			qw422016.N().S(`: <a href="`)
-			//line contributors/newRequestsList.qtpl:43
-			qw422016.E().S(param)
-			//line contributors/newRequestsList.qtpl:43
-			qw422016.N().S(`" target="_blank">link</a>
                 <br>
+                <code>`)
+			//line contributors/newRequestsList.qtpl:148
+			qw422016.E().S(request.RawParameters)
+			//line contributors/newRequestsList.qtpl:148
+			qw422016.N().S(`</code>
+            `)
+		//line contributors/newRequestsList.qtpl:149
+		} else {
+			//line contributors/newRequestsList.qtpl:149
+			qw422016.N().S(`
+                `)
+			//line contributors/newRequestsList.qtpl:150
+			for _, param := range request.Parameters {
+				//line contributors/newRequestsList.qtpl:150
+				qw422016.N().S(`
+                    `)
+				//line contributors/newRequestsList.qtpl:151
+				qw422016.E().S(param.Name)
+				//line contributors/newRequestsList.qtpl:151
+				qw422016.N().S(`: `)
+				//line contributors/newRequestsList.qtpl:151
+				streammissionParameter(qw422016, param)
+				//line contributors/newRequestsList.qtpl:151
+				qw422016.N().S(`
+                    <br>
+                `)
+			//line contributors/newRequestsList.qtpl:153
+			}
+			//line contributors/newRequestsList.qtpl:153
+			qw422016.N().S(`
             `)
-			//line contributors/newRequestsList.qtpl:45
+		//line contributors/newRequestsList.qtpl:154
 		}
-		//line contributors/newRequestsList.qtpl:45
+		//line contributors/newRequestsList.qtpl:154
 		qw422016.N().S(`
             </td>
-            <td><button type="submit" name="status" value="approved">Одобрить</button>
+            <td>`)
+		//line contributors/newRequestsList.qtpl:156
+		qw422016.E().S(request.RejectionReasonTitle)
+		//line contributors/newRequestsList.qtpl:156
+		qw422016.N().S(`</td>
+            <td>`)
+		//line contributors/newRequestsList.qtpl:157
+		qw422016.E().S(request.ModeratorComment)
+		//line contributors/newRequestsList.qtpl:157
+		qw422016.N().S(`</td>
+            <td>
+            `)
+		//line contributors/newRequestsList.qtpl:159
+		if request.Status == contributor.StatusNew {
+			//line contributors/newRequestsList.qtpl:159
+			qw422016.N().S(`
+                <select name="reason">
+                    <option value="">Причина отказа</option>
+                `)
+			//line contributors/newRequestsList.qtpl:162
+			for _, reason := range p.Reasons {
+				//line contributors/newRequestsList.qtpl:162
+				qw422016.N().S(`
+                    <option value="`)
+				//line contributors/newRequestsList.qtpl:163
+				qw422016.E().S(reason.Code)
+				//line contributors/newRequestsList.qtpl:163
+				qw422016.N().S(`">`)
+				//line contributors/newRequestsList.qtpl:163
+				qw422016.E().S(reason.Title)
+				//line contributors/newRequestsList.qtpl:163
+				qw422016.N().S(`</option>
+                `)
+			//line contributors/newRequestsList.qtpl:164
+			}
+			//line contributors/newRequestsList.qtpl:164
+			qw422016.N().S(`
+                </select>
+                <input type="text" name="comment" placeholder="Комментарий">
+                <button type="submit" name="status" value="approved">Одобрить</button>
                 <button type="submit" name="status" value="rejected">Отклонить</button>
+            `)
+		//line contributors/newRequestsList.qtpl:169
+		}
+		//line contributors/newRequestsList.qtpl:169
+		qw422016.N().S(`
+                <a href="/admin/UserRequestStatusHistory?id=`)
+		//line contributors/newRequestsList.qtpl:170
+		qw422016.N().D(int(request.ID))
+		//line contributors/newRequestsList.qtpl:170
+		qw422016.N().S(`">История</a>
             </td>
 	    </tr>
 	    </form>
 	`)
-		//line contributors/newRequestsList.qtpl:52
+	//line contributors/newRequestsList.qtpl:174
 	}
-	//line contributors/newRequestsList.qtpl:52
+	//line contributors/newRequestsList.qtpl:174
 	qw422016.N().S(`
 	    </tbody>
 	</table>
+
+    <p>
+        `)
+	//line contributors/newRequestsList.qtpl:179
+	if p.Query.Get("cursor") != "" {
+		//line contributors/newRequestsList.qtpl:179
+		qw422016.N().S(`
+            <a href="`)
+		//line contributors/newRequestsList.qtpl:180
+		qw422016.E().S(p.pageURL(""))
+		//line contributors/newRequestsList.qtpl:180
+		qw422016.N().S(`">В начало</a>
+        `)
+	//line contributors/newRequestsList.qtpl:181
+	}
+	//line contributors/newRequestsList.qtpl:181
+	qw422016.N().S(`
+        `)
+	//line contributors/newRequestsList.qtpl:182
+	if p.NextCursor != "" {
+		//line contributors/newRequestsList.qtpl:182
+		qw422016.N().S(`
+            <a href="`)
+		//line contributors/newRequestsList.qtpl:183
+		qw422016.E().S(p.pageURL(p.NextCursor))
+		//line contributors/newRequestsList.qtpl:183
+		qw422016.N().S(`">Далее</a>
+        `)
+	//line contributors/newRequestsList.qtpl:184
+	}
+	//line contributors/newRequestsList.qtpl:184
+	qw422016.N().S(`
+    </p>
 `)
-//line contributors/newRequestsList.qtpl:55
+//line contributors/newRequestsList.qtpl:186
 }
 
-//line contributors/newRequestsList.qtpl:55
+//line contributors/newRequestsList.qtpl:186
 func (p *NewRequestsListPage) WriteBody(qq422016 qtio422016.Writer) {
-	//line contributors/newRequestsList.qtpl:55
+	//line contributors/newRequestsList.qtpl:186
 	qw422016 := qt422016.AcquireWriter(qq422016)
-	//line contributors/newRequestsList.qtpl:55
+	//line contributors/newRequestsList.qtpl:186
 	p.StreamBody(qw422016)
-	//line contributors/newRequestsList.qtpl:55
+	//line contributors/newRequestsList.qtpl:186
 	qt422016.ReleaseWriter(qw422016)
-//line contributors/newRequestsList.qtpl:55
+//line contributors/newRequestsList.qtpl:186
 }
 
-//line contributors/newRequestsList.qtpl:55
+//line contributors/newRequestsList.qtpl:186
 func (p *NewRequestsListPage) Body() string {
-	//line contributors/newRequestsList.qtpl:55
+	//line contributors/newRequestsList.qtpl:186
 	qb422016 := qt422016.AcquireByteBuffer()
-	//line contributors/newRequestsList.qtpl:55
+	//line contributors/newRequestsList.qtpl:186
 	p.WriteBody(qb422016)
-	//line contributors/newRequestsList.qtpl:55
+	//line contributors/newRequestsList.qtpl:186
 	qs422016 := string(qb422016.B)
-	//line contributors/newRequestsList.qtpl:55
+	//line contributors/newRequestsList.qtpl:186
 	qt422016.ReleaseByteBuffer(qb422016)
-	//line contributors/newRequestsList.qtpl:55
+	//line contributors/newRequestsList.qtpl:186
 	return qs422016
-//line contributors/newRequestsList.qtpl:55
+//line contributors/newRequestsList.qtpl:186
 }
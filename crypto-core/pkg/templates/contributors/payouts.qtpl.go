@@ -0,0 +1,264 @@
+// This file is automatically generated by qtc from "payouts.qtpl".
+// See https://github.com/valyala/quicktemplate for details.
+
+// Payout ledger page template. Implements Page methods.
+//
+
+//line contributors/payouts.qtpl:3
+package contributors
+
+//line contributors/payouts.qtpl:3
+import (
+	timeformat "github.com/bfg-dev/crypto-core/pkg/helpers/timeformat"
+	payout "github.com/bfg-dev/crypto-core/pkg/services/payout"
+)
+
+//line contributors/payouts.qtpl:9
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line contributors/payouts.qtpl:9
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line contributors/payouts.qtpl:10
+type PayoutsPage struct {
+	BasePage
+	Pending []payout.Payout
+	Batches []payout.Batch
+}
+
+//line contributors/payouts.qtpl:17
+func (p *PayoutsPage) StreamTitle(qw422016 *qt422016.Writer) {
+	//line contributors/payouts.qtpl:17
+	qw422016.N().S(`
+	Выплаты
+`)
+//line contributors/payouts.qtpl:19
+}
+
+//line contributors/payouts.qtpl:19
+func (p *PayoutsPage) WriteTitle(qq422016 qtio422016.Writer) {
+	//line contributors/payouts.qtpl:19
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/payouts.qtpl:19
+	p.StreamTitle(qw422016)
+	//line contributors/payouts.qtpl:19
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/payouts.qtpl:19
+}
+
+//line contributors/payouts.qtpl:19
+func (p *PayoutsPage) Title() string {
+	//line contributors/payouts.qtpl:19
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/payouts.qtpl:19
+	p.WriteTitle(qb422016)
+	//line contributors/payouts.qtpl:19
+	qs422016 := string(qb422016.B)
+	//line contributors/payouts.qtpl:19
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/payouts.qtpl:19
+	return qs422016
+//line contributors/payouts.qtpl:19
+}
+
+//line contributors/payouts.qtpl:21
+func (p *PayoutsPage) StreamBody(qw422016 *qt422016.Writer) {
+	//line contributors/payouts.qtpl:21
+	qw422016.N().S(`
+
+    <p><a href="/admin/NewUserMissionRequests">Запросы на миссии</a> <a href="/admin/missions">Миссии</a></p>
+
+    <h2>Ожидают выплаты</h2>
+
+    <form id="batch" method="post" action="/admin/payouts/batches">
+        `)
+	//line contributors/payouts.qtpl:28
+	p.StreamCSRFField(qw422016)
+	//line contributors/payouts.qtpl:28
+	qw422016.N().S(`
+        <button type="submit">Создать пакет из выбранных</button>
+    </form>
+
+	<table>
+	    <thead>
+	        <tr>
+	            <th>&nbsp;</th>
+	            <th>Дата</th>
+	            <th>Пользователь</th>
+	            <th>Миссия</th>
+	            <th>Сумма</th>
+	        </tr>
+	    </thead>
+	    <tbody>
+	`)
+	//line contributors/payouts.qtpl:43
+	for _, item := range p.Pending {
+		//line contributors/payouts.qtpl:43
+		qw422016.N().S(`
+	    <tr>
+	        <td><input type="checkbox" form="batch" name="ids" value="`)
+		//line contributors/payouts.qtpl:45
+		qw422016.N().DL(item.ID)
+		//line contributors/payouts.qtpl:45
+		qw422016.N().S(`" checked></td>
+	        <td>`)
+		//line contributors/payouts.qtpl:46
+		qw422016.E().S(item.CreatedAt.Format(timeformat.Date))
+		//line contributors/payouts.qtpl:46
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/payouts.qtpl:47
+		qw422016.E().S(item.UserEmail)
+		//line contributors/payouts.qtpl:47
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/payouts.qtpl:48
+		qw422016.E().S(item.Mission)
+		//line contributors/payouts.qtpl:48
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/payouts.qtpl:49
+		qw422016.E().S(item.Tokens().String())
+		//line contributors/payouts.qtpl:49
+		qw422016.N().S(`</td>
+	    </tr>
+	`)
+	//line contributors/payouts.qtpl:51
+	}
+	//line contributors/payouts.qtpl:51
+	qw422016.N().S(`
+	    </tbody>
+	</table>
+
+    <h2>Пакеты выплат</h2>
+
+	<table>
+	    <thead>
+	        <tr>
+	            <th>ID</th>
+	            <th>Создан</th>
+	            <th>Статус</th>
+	            <th>Выплат</th>
+	            <th>Сумма</th>
+	            <th>Платёж</th>
+	            <th>&nbsp;</th>
+	        </tr>
+	    </thead>
+	    <tbody>
+	`)
+	//line contributors/payouts.qtpl:70
+	for _, batch := range p.Batches {
+		//line contributors/payouts.qtpl:70
+		qw422016.N().S(`
+	    <tr>
+	        <td>`)
+		//line contributors/payouts.qtpl:72
+		qw422016.N().DL(batch.ID)
+		//line contributors/payouts.qtpl:72
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/payouts.qtpl:73
+		qw422016.E().S(batch.CreatedAt.Format(timeformat.Date))
+		//line contributors/payouts.qtpl:73
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/payouts.qtpl:74
+		qw422016.E().S(string(batch.Status))
+		//line contributors/payouts.qtpl:74
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/payouts.qtpl:75
+		qw422016.N().D(batch.Count)
+		//line contributors/payouts.qtpl:75
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/payouts.qtpl:76
+		qw422016.E().S(batch.TotalTokens().String())
+		//line contributors/payouts.qtpl:76
+		qw422016.N().S(`</td>
+	        <td>`)
+		//line contributors/payouts.qtpl:77
+		qw422016.E().S(batch.Reference)
+		//line contributors/payouts.qtpl:77
+		qw422016.N().S(`</td>
+	        <td>
+	            <form method="post" action="/admin/payouts/batches/`)
+		//line contributors/payouts.qtpl:79
+		qw422016.N().DL(batch.ID)
+		//line contributors/payouts.qtpl:79
+		qw422016.N().S(`/export">
+	                `)
+		//line contributors/payouts.qtpl:80
+		p.StreamCSRFField(qw422016)
+		//line contributors/payouts.qtpl:80
+		qw422016.N().S(`
+	                <button type="submit">Выгрузить CSV</button>
+	            </form>
+	        `)
+		//line contributors/payouts.qtpl:83
+		if batch.Status != payout.BatchPaid {
+			//line contributors/payouts.qtpl:83
+			qw422016.N().S(`
+	            <form method="post" action="/admin/payouts/batches/`)
+			//line contributors/payouts.qtpl:84
+			qw422016.N().DL(batch.ID)
+			//line contributors/payouts.qtpl:84
+			qw422016.N().S(`/paid">
+	                `)
+			//line contributors/payouts.qtpl:85
+			p.StreamCSRFField(qw422016)
+			//line contributors/payouts.qtpl:85
+			qw422016.N().S(`
+	                <input type="text" name="reference" placeholder="Номер платежа">
+	                <button type="submit">Выплачено</button>
+	            </form>
+	        `)
+		//line contributors/payouts.qtpl:89
+		}
+		//line contributors/payouts.qtpl:89
+		qw422016.N().S(`
+	        </td>
+	    </tr>
+	`)
+	//line contributors/payouts.qtpl:92
+	}
+	//line contributors/payouts.qtpl:92
+	qw422016.N().S(`
+	    </tbody>
+	</table>
+`)
+//line contributors/payouts.qtpl:95
+}
+
+//line contributors/payouts.qtpl:95
+func (p *PayoutsPage) WriteBody(qq422016 qtio422016.Writer) {
+	//line contributors/payouts.qtpl:95
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/payouts.qtpl:95
+	p.StreamBody(qw422016)
+	//line contributors/payouts.qtpl:95
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/payouts.qtpl:95
+}
+
+//line contributors/payouts.qtpl:95
+func (p *PayoutsPage) Body() string {
+	//line contributors/payouts.qtpl:95
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/payouts.qtpl:95
+	p.WriteBody(qb422016)
+	//line contributors/payouts.qtpl:95
+	qs422016 := string(qb422016.B)
+	//line contributors/payouts.qtpl:95
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/payouts.qtpl:95
+	return qs422016
+//line contributors/payouts.qtpl:95
+}
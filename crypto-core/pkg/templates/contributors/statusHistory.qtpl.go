@@ -0,0 +1,180 @@
+// This file is automatically generated by qtc from "statusHistory.qtpl".
+// See https://github.com/valyala/quicktemplate for details.
+
+// Status history page of one mission request. Implements Page methods.
+//
+
+//line contributors/statusHistory.qtpl:3
+package contributors
+
+//line contributors/statusHistory.qtpl:3
+import (
+	timeformat "github.com/bfg-dev/crypto-core/pkg/helpers/timeformat"
+	contributor "github.com/bfg-dev/crypto-core/pkg/services/contributor"
+)
+
+//line contributors/statusHistory.qtpl:9
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line contributors/statusHistory.qtpl:9
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line contributors/statusHistory.qtpl:10
+type StatusHistoryPage struct {
+	BasePage
+	RequestID int64
+	History   []contributor.StatusHistoryEntry
+}
+
+//line contributors/statusHistory.qtpl:17
+func (p *StatusHistoryPage) StreamTitle(qw422016 *qt422016.Writer) {
+	//line contributors/statusHistory.qtpl:17
+	qw422016.N().S(`
+	История запроса
+`)
+//line contributors/statusHistory.qtpl:19
+}
+
+//line contributors/statusHistory.qtpl:19
+func (p *StatusHistoryPage) WriteTitle(qq422016 qtio422016.Writer) {
+	//line contributors/statusHistory.qtpl:19
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/statusHistory.qtpl:19
+	p.StreamTitle(qw422016)
+	//line contributors/statusHistory.qtpl:19
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/statusHistory.qtpl:19
+}
+
+//line contributors/statusHistory.qtpl:19
+func (p *StatusHistoryPage) Title() string {
+	//line contributors/statusHistory.qtpl:19
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/statusHistory.qtpl:19
+	p.WriteTitle(qb422016)
+	//line contributors/statusHistory.qtpl:19
+	qs422016 := string(qb422016.B)
+	//line contributors/statusHistory.qtpl:19
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/statusHistory.qtpl:19
+	return qs422016
+//line contributors/statusHistory.qtpl:19
+}
+
+//line contributors/statusHistory.qtpl:21
+func (p *StatusHistoryPage) StreamBody(qw422016 *qt422016.Writer) {
+	//line contributors/statusHistory.qtpl:21
+	qw422016.N().S(`
+
+    <h2>История статусов запроса #`)
+	//line contributors/statusHistory.qtpl:23
+	qw422016.N().DL(p.RequestID)
+	//line contributors/statusHistory.qtpl:23
+	qw422016.N().S(`</h2>
+
+	<table>
+	    <thead>
+	        <tr>
+	            <th>Дата</th>
+	            <th>Кто изменил</th>
+	            <th>Старый статус</th>
+	            <th>Новый статус</th>
+	            <th>Комментарий</th>
+	        </tr>
+	    </thead>
+	    <tbody>
+	`)
+	//line contributors/statusHistory.qtpl:36
+	for _, entry := range p.History {
+		//line contributors/statusHistory.qtpl:36
+		qw422016.N().S(`
+	    <tr>
+	        <td>`)
+		//line contributors/statusHistory.qtpl:38
+		qw422016.E().S(entry.CreatedAt.Format(timeformat.Date))
+		//line contributors/statusHistory.qtpl:38
+		qw422016.N().S(`</td>
+            <td>
+            `)
+		//line contributors/statusHistory.qtpl:40
+		if entry.ActorID == 0 {
+			//line contributors/statusHistory.qtpl:40
+			qw422016.N().S(`
+                Участник
+            `)
+		//line contributors/statusHistory.qtpl:42
+		} else {
+			//line contributors/statusHistory.qtpl:42
+			qw422016.N().S(`
+                `)
+			//line contributors/statusHistory.qtpl:43
+			qw422016.E().S(entry.ActorEmail)
+			//line contributors/statusHistory.qtpl:43
+			qw422016.N().S(`
+            `)
+		//line contributors/statusHistory.qtpl:44
+		}
+		//line contributors/statusHistory.qtpl:44
+		qw422016.N().S(`
+            </td>
+            <td>`)
+		//line contributors/statusHistory.qtpl:46
+		qw422016.E().S(string(entry.OldStatus))
+		//line contributors/statusHistory.qtpl:46
+		qw422016.N().S(`</td>
+            <td>`)
+		//line contributors/statusHistory.qtpl:47
+		qw422016.E().S(string(entry.NewStatus))
+		//line contributors/statusHistory.qtpl:47
+		qw422016.N().S(`</td>
+            <td>`)
+		//line contributors/statusHistory.qtpl:48
+		qw422016.E().S(entry.Comment)
+		//line contributors/statusHistory.qtpl:48
+		qw422016.N().S(`</td>
+	    </tr>
+	`)
+	//line contributors/statusHistory.qtpl:50
+	}
+	//line contributors/statusHistory.qtpl:50
+	qw422016.N().S(`
+	    </tbody>
+	</table>
+
+    <p><a href="/admin/NewUserMissionRequests">Вернуться к списку запросов</a></p>
+`)
+//line contributors/statusHistory.qtpl:55
+}
+
+//line contributors/statusHistory.qtpl:55
+func (p *StatusHistoryPage) WriteBody(qq422016 qtio422016.Writer) {
+	//line contributors/statusHistory.qtpl:55
+	qw422016 := qt422016.AcquireWriter(qq422016)
+	//line contributors/statusHistory.qtpl:55
+	p.StreamBody(qw422016)
+	//line contributors/statusHistory.qtpl:55
+	qt422016.ReleaseWriter(qw422016)
+//line contributors/statusHistory.qtpl:55
+}
+
+//line contributors/statusHistory.qtpl:55
+func (p *StatusHistoryPage) Body() string {
+	//line contributors/statusHistory.qtpl:55
+	qb422016 := qt422016.AcquireByteBuffer()
+	//line contributors/statusHistory.qtpl:55
+	p.WriteBody(qb422016)
+	//line contributors/statusHistory.qtpl:55
+	qs422016 := string(qb422016.B)
+	//line contributors/statusHistory.qtpl:55
+	qt422016.ReleaseByteBuffer(qb422016)
+	//line contributors/statusHistory.qtpl:55
+	return qs422016
+//line contributors/statusHistory.qtpl:55
+}
@@ -0,0 +1,98 @@
+//go:build integration
+
+// Package testutil holds helpers of integration tests running against postgres
+package testutil
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/bfg-dev/crypto-core/pkg/entities"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// DB connects to CRYPTO_TEST_DB_DSN, a database with the application schema and migrations applied,
+// and begins a transaction rolled back on cleanup, so rows of the test are never seen by others.
+// The pool keeps its only connection, every query of the test runs in that transaction.
+// Repository methods under test must not begin transactions of their own
+func DB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv("CRYPTO_TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("CRYPTO_TEST_DB_DSN is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("unable to connect to test database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	if _, err = db.Exec("BEGIN"); err != nil {
+		db.Close()
+		t.Fatalf("unable to begin test transaction: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if _, err := db.Exec("ROLLBACK"); err != nil {
+			t.Errorf("unable to roll back test transaction: %v", err)
+		}
+		db.Close()
+	})
+	return db
+}
+
+// CreateMission inserts a mission with the title
+func CreateMission(t *testing.T, db *sqlx.DB, title string) int64 {
+	t.Helper()
+
+	var id int64
+	err := db.QueryRowxContext(context.Background(), `
+		INSERT INTO
+			"ccMissions" (title)
+		VALUES
+			($1)
+		RETURNING id`, title).Scan(&id)
+	if err != nil {
+		t.Fatalf("unable to insert mission: %v", err)
+	}
+	return id
+}
+
+// CreateUser inserts a user, nil names and e-mail are stored as NULL
+func CreateUser(t *testing.T, db *sqlx.DB, firstName, lastName, email *string) int64 {
+	t.Helper()
+
+	var id int64
+	err := db.QueryRowxContext(context.Background(), `
+		INSERT INTO
+			"users" (firstname, lastname, email)
+		VALUES
+			($1, $2, $3)
+		RETURNING id`, firstName, lastName, email).Scan(&id)
+	if err != nil {
+		t.Fatalf("unable to insert user: %v", err)
+	}
+	return id
+}
+
+// CreateRequest inserts a mission request of the user in the status with empty parameters
+func CreateRequest(t *testing.T, db *sqlx.DB, userID, missionID int64, status entities.UserMissionStatus) int64 {
+	t.Helper()
+
+	var id int64
+	err := db.QueryRowxContext(context.Background(), `
+		INSERT INTO
+			"ccUserMissions" ("createdAt", "userId", "missionId", "status", "missionParameters")
+		VALUES
+			(now(), $1, $2, $3, '{}')
+		RETURNING id`, userID, missionID, status).Scan(&id)
+	if err != nil {
+		t.Fatalf("unable to insert request: %v", err)
+	}
+	return id
+}
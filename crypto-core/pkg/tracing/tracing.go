@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters supported by Init
+const (
+	ExporterNone   = ""
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+const tracerName = "github.com/bfg-dev/crypto-core"
+
+// Init configures global tracer provider and trace context propagation.
+// exporter is one of Exporter* constants, endpoint is used by otlp exporter only (host:port of collector).
+// With ExporterNone spans are not recorded. Returned function flushes and stops the provider
+func Init(serviceName string, exporter string, endpoint string) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+
+	switch exporter {
+	case ExporterNone:
+		return func(ctx context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		options := []otlptracehttp.Option{otlptracehttp.WithInsecure()}
+		if endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(endpoint))
+		}
+		spanExporter, err = otlptracehttp.New(context.Background(), options...)
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, errors.Errorf("tracing.Init, unknown exporter %q", exporter)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "tracing.Init, unable to create %s exporter", exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts child span of the span in ctx
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan marks span as failed when err is not nil and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// HeadersCarrier adapts amqp message headers to trace context propagation
+type HeadersCarrier map[string]interface{}
+
+func (c HeadersCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+func (c HeadersCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+func (c HeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// InjectHeaders puts trace context of ctx into amqp message headers
+func InjectHeaders(ctx context.Context, headers map[string]interface{}) {
+	otel.GetTextMapPropagator().Inject(ctx, HeadersCarrier(headers))
+}
+
+// ExtractHeaders returns ctx continuing trace context of amqp message headers, consumers start their spans from it
+func ExtractHeaders(ctx context.Context, headers map[string]interface{}) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, HeadersCarrier(headers))
+}
+
+// TraceContext returns trace context of ctx to be stored with queued work
+func TraceContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// WithTraceContext returns ctx continuing trace context returned by TraceContext
+func WithTraceContext(ctx context.Context, traceContext map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(traceContext))
+}